@@ -0,0 +1,85 @@
+package fileset
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/moby/patternmatcher"
+	"github.com/moby/patternmatcher/ignorefile"
+)
+
+// WalkFunc is called for every file and directory of the set with its slash-separated path relative to the root
+type WalkFunc func(relPath string, entry fs.DirEntry) error
+
+// ReadIgnoreFile returns the patterns of an ignore file such as .dockerignore, a missing file has no pattern
+func ReadIgnoreFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	patterns, err := ignorefile.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return patterns, nil
+}
+
+// Walk walks the root directory in lexical order, skipping the paths excluded by the patterns.
+// Patterns follow the .dockerignore syntax: a path is excluded when it or one of its parent directories matches,
+// and a pattern starting with ! includes again the paths matched by the previous patterns
+func Walk(root string, patterns []string, fn WalkFunc) error {
+	matcher, err := patternmatcher.New(patterns)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	parentMatches := make(map[string]patternmatcher.MatchInfo)
+
+	return filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+
+		if relPath == "." {
+			return nil
+		}
+
+		relPath = filepath.ToSlash(relPath)
+
+		excluded, matchInfo, err := matcher.MatchesUsingParentResults(relPath, parentMatches[path.Dir(relPath)])
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			parentMatches[relPath] = matchInfo
+		}
+
+		if excluded {
+			// The content of an excluded directory can only be included again by an exclusion pattern
+			if entry.IsDir() && !matcher.Exclusions() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		return fn(relPath, entry)
+	})
+}
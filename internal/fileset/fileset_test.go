@@ -0,0 +1,91 @@
+package fileset_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/fileset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func walkedPaths(t *testing.T, root string, patterns []string) []string {
+	t.Helper()
+
+	var paths []string
+
+	err := fileset.Walk(root, patterns, func(relPath string, _ fs.DirEntry) error {
+		paths = append(paths, relPath)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return paths
+}
+
+func TestWalk(t *testing.T) {
+	root := t.TempDir()
+
+	for _, file := range []string{"main.go", "README.md", "docs/guide.md", "docs/keep.md", "node_modules/lib/index.js", "tmp/cache.bin"} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(file), 0o600))
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		expected []string
+	}{
+		{
+			name:     "noPattern",
+			patterns: nil,
+			expected: []string{"README.md", "docs", "docs/guide.md", "docs/keep.md", "main.go", "node_modules", "node_modules/lib", "node_modules/lib/index.js", "tmp", "tmp/cache.bin"},
+		},
+		{
+			name:     "excludedDirectories",
+			patterns: []string{"node_modules", "tmp/"},
+			expected: []string{"README.md", "docs", "docs/guide.md", "docs/keep.md", "main.go"},
+		},
+		{
+			name:     "doubleStar",
+			patterns: []string{"**/*.md"},
+			expected: []string{"docs", "main.go", "node_modules", "node_modules/lib", "node_modules/lib/index.js", "tmp", "tmp/cache.bin"},
+		},
+		{
+			name:     "exclusion",
+			patterns: []string{"docs", "!docs/keep.md", "node_modules", "tmp"},
+			expected: []string{"README.md", "docs/keep.md", "main.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, walkedPaths(t, root, tt.patterns))
+		})
+	}
+}
+
+func TestWalk_InvalidPattern(t *testing.T) {
+	err := fileset.Walk(t.TempDir(), []string{"["}, func(string, fs.DirEntry) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
+
+func TestReadIgnoreFile(t *testing.T) {
+	dir := t.TempDir()
+
+	patterns, err := fileset.ReadIgnoreFile(filepath.Join(dir, ".dockerignore"))
+	require.NoError(t, err)
+	assert.Empty(t, patterns)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".dockerignore"), []byte("# comment\n/tmp/\n\n!tmp/keep\n"), 0o600))
+
+	patterns, err = fileset.ReadIgnoreFile(filepath.Join(dir, ".dockerignore"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tmp", "!tmp/keep"}, patterns)
+}
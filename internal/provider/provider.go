@@ -126,6 +126,7 @@ func Provider(config *Config) plugin.ProviderFunc {
 				"scaleway_apple_silicon_server":                applesilicon.ResourceServer(),
 				"scaleway_baremetal_server":                    baremetal.ResourceServer(),
 				"scaleway_block_snapshot":                      block.ResourceSnapshot(),
+				"scaleway_block_snapshot_policy":               block.ResourceSnapshotPolicy(),
 				"scaleway_block_volume":                        block.ResourceVolume(),
 				"scaleway_cockpit":                             cockpit.ResourceCockpit(),
 				"scaleway_cockpit_source":                      cockpit.ResourceCockpitSource(),
@@ -158,6 +159,7 @@ func Provider(config *Config) plugin.ProviderFunc {
 				"scaleway_inference_deployment":                inference.ResourceDeployment(),
 				"scaleway_instance_image":                      instance.ResourceImage(),
 				"scaleway_instance_ip":                         instance.ResourceIP(),
+				"scaleway_instance_ip_attachment":              instance.ResourceIPAttachment(),
 				"scaleway_instance_ip_reverse_dns":             instance.ResourceIPReverseDNS(),
 				"scaleway_instance_placement_group":            instance.ResourcePlacementGroup(),
 				"scaleway_instance_private_nic":                instance.ResourcePrivateNIC(),
@@ -174,15 +176,19 @@ func Provider(config *Config) plugin.ProviderFunc {
 				"scaleway_ipam_ip":                             ipam.ResourceIP(),
 				"scaleway_ipam_ip_reverse_dns":                 ipam.ResourceIPReverseDNS(),
 				"scaleway_job_definition":                      jobs.ResourceDefinition(),
+				"scaleway_k8s_acl":                             k8s.ResourceACL(),
 				"scaleway_k8s_cluster":                         k8s.ResourceCluster(),
+				"scaleway_k8s_node_action":                     k8s.ResourceNodeAction(),
 				"scaleway_k8s_pool":                            k8s.ResourcePool(),
 				"scaleway_lb":                                  lb.ResourceLb(),
 				"scaleway_lb_acl":                              lb.ResourceACL(),
+				"scaleway_lb_acl_set":                          lb.ResourceACLSet(),
 				"scaleway_lb_backend":                          lb.ResourceBackend(),
 				"scaleway_lb_certificate":                      lb.ResourceCertificate(),
 				"scaleway_lb_frontend":                         lb.ResourceFrontend(),
 				"scaleway_lb_ip":                               lb.ResourceIP(),
 				"scaleway_lb_route":                            lb.ResourceRoute(),
+				"scaleway_lb_subscriber":                       lb.ResourceSubscriber(),
 				"scaleway_mnq_nats_account":                    mnq.ResourceNatsAccount(),
 				"scaleway_mnq_nats_credentials":                mnq.ResourceNatsCredentials(),
 				"scaleway_mnq_sns":                             mnq.ResourceSNS(),
@@ -194,6 +200,7 @@ func Provider(config *Config) plugin.ProviderFunc {
 				"scaleway_mnq_sqs_queue":                       mnq.ResourceSQSQueue(),
 				"scaleway_mongodb_instance":                    mongodb.ResourceInstance(),
 				"scaleway_mongodb_snapshot":                    mongodb.ResourceSnapshot(),
+				"scaleway_mongodb_user":                        mongodb.ResourceUser(),
 				"scaleway_object":                              object.ResourceObject(),
 				"scaleway_object_bucket":                       object.ResourceBucket(),
 				"scaleway_object_bucket_acl":                   object.ResourceBucketACL(),
@@ -203,14 +210,23 @@ func Provider(config *Config) plugin.ProviderFunc {
 				"scaleway_rdb_acl":                             rdb.ResourceACL(),
 				"scaleway_rdb_database":                        rdb.ResourceDatabase(),
 				"scaleway_rdb_database_backup":                 rdb.ResourceDatabaseBackup(),
+				"scaleway_rdb_database_backup_restore":         rdb.ResourceDatabaseBackupRestore(),
+				"scaleway_rdb_endpoint":                        rdb.ResourceEndpoint(),
 				"scaleway_rdb_instance":                        rdb.ResourceInstance(),
+				"scaleway_rdb_instance_logs_export":            rdb.ResourceInstanceLogsExport(),
 				"scaleway_rdb_privilege":                       rdb.ResourcePrivilege(),
 				"scaleway_rdb_read_replica":                    rdb.ResourceReadReplica(),
 				"scaleway_rdb_user":                            rdb.ResourceUser(),
 				"scaleway_rdb_snapshot":                        rdb.ResourceSnapshot(),
+				"scaleway_redis_acl_rule":                      redis.ResourceACLRule(),
 				"scaleway_redis_cluster":                       redis.ResourceCluster(),
+				"scaleway_redis_cluster_settings":              redis.ResourceClusterSettings(),
+				"scaleway_registry_image":                      registry.ResourceImage(),
 				"scaleway_registry_namespace":                  registry.ResourceNamespace(),
+				"scaleway_registry_retention_policy":           registry.ResourceRetentionPolicy(),
 				"scaleway_sdb_sql_database":                    sdb.ResourceDatabase(),
+				"scaleway_sdb_sql_database_backup_export":      sdb.ResourceDatabaseBackupExport(),
+				"scaleway_sdb_sql_database_backup_restore":     sdb.ResourceDatabaseBackupRestore(),
 				"scaleway_secret":                              secret.ResourceSecret(),
 				"scaleway_secret_version":                      secret.ResourceVersion(),
 				"scaleway_tem_domain":                          tem.ResourceDomain(),
@@ -272,11 +288,13 @@ func Provider(config *Config) plugin.ProviderFunc {
 				"scaleway_ipam_ip":                             ipam.DataSourceIP(),
 				"scaleway_ipam_ips":                            ipam.DataSourceIPs(),
 				"scaleway_k8s_cluster":                         k8s.DataSourceCluster(),
+				"scaleway_k8s_nodes":                           k8s.DataSourceNodes(),
 				"scaleway_k8s_pool":                            k8s.DataSourcePool(),
 				"scaleway_k8s_version":                         k8s.DataSourceVersion(),
 				"scaleway_lb":                                  lb.DataSourceLb(),
 				"scaleway_lb_acls":                             lb.DataSourceACLs(),
 				"scaleway_lb_backend":                          lb.DataSourceBackend(),
+				"scaleway_lb_backend_stats":                    lb.DataSourceBackendStats(),
 				"scaleway_lb_backends":                         lb.DataSourceBackends(),
 				"scaleway_lb_certificate":                      lb.DataSourceCertificate(),
 				"scaleway_lb_frontend":                         lb.DataSourceFrontend(),
@@ -285,6 +303,7 @@ func Provider(config *Config) plugin.ProviderFunc {
 				"scaleway_lb_ips":                              lb.DataSourceIPs(),
 				"scaleway_lb_route":                            lb.DataSourceRoute(),
 				"scaleway_lb_routes":                           lb.DataSourceRoutes(),
+				"scaleway_lb_stats":                            lb.DataSourceStats(),
 				"scaleway_lbs":                                 lb.DataSourceLbs(),
 				"scaleway_marketplace_image":                   marketplace.DataSourceImage(),
 				"scaleway_mnq_sqs":                             mnq.DataSourceSQS(),
@@ -295,12 +314,16 @@ func Provider(config *Config) plugin.ProviderFunc {
 				"scaleway_rdb_acl":                             rdb.DataSourceACL(),
 				"scaleway_rdb_database":                        rdb.DataSourceDatabase(),
 				"scaleway_rdb_database_backup":                 rdb.DataSourceDatabaseBackup(),
+				"scaleway_rdb_engine_settings":                 rdb.DataSourceEngineSettings(),
 				"scaleway_rdb_instance":                        rdb.DataSourceInstance(),
+				"scaleway_rdb_instance_logs":                   rdb.DataSourceInstanceLogs(),
 				"scaleway_rdb_privilege":                       rdb.DataSourcePrivilege(),
 				"scaleway_redis_cluster":                       redis.DataSourceCluster(),
 				"scaleway_registry_image":                      registry.DataSourceImage(),
 				"scaleway_registry_namespace":                  registry.DataSourceNamespace(),
 				"scaleway_registry_image_tag":                  registry.DataSourceImageTag(),
+				"scaleway_sdb_sql_database_backup":             sdb.DataSourceDatabaseBackup(),
+				"scaleway_sdb_sql_database_backups":            sdb.DataSourceDatabaseBackups(),
 				"scaleway_secret":                              secret.DataSourceSecret(),
 				"scaleway_secret_version":                      secret.DataSourceVersion(),
 				"scaleway_tem_domain":                          tem.DataSourceDomain(),
@@ -2,10 +2,14 @@ package block
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/robfig/cron/v3"
 	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -19,6 +23,12 @@ const (
 	defaultBlockTimeout       = 5 * time.Minute
 	defaultBlockRetryInterval = 5 * time.Second
 	BlockVolumeType           = instance.VolumeServerVolumeType("sbs_volume")
+
+	defaultSnapshotPolicyTimeout      = 30 * time.Minute
+	defaultSnapshotPolicyNameTemplate = "{volume_name}-{timestamp}"
+	snapshotPolicyTagPrefix           = "snapshot-policy="
+	snapshotPolicyRunTagPrefix        = "snapshot-policy-run="
+	snapshotPolicyRunTagLayout        = "20060102T150405Z"
 )
 
 // blockAPIWithZone returns a new block API and the zone for a Create request
@@ -94,3 +104,188 @@ func migrateInstanceToBlockVolume(ctx context.Context, api *instancehelpers.Bloc
 
 	return blockVolume, nil
 }
+
+// SnapshotPolicySnapshot is a snapshot created by a snapshot policy, from either the block or the instance API
+type SnapshotPolicySnapshot struct {
+	ID        string
+	VolumeID  string
+	CreatedAt time.Time
+	Tags      []string
+	// IsBlock is true if the snapshot is managed by the block API
+	IsBlock bool
+}
+
+// SnapshotPolicyTag returns the tag set on every snapshot created by the policy
+func SnapshotPolicyTag(policyID string) string {
+	return snapshotPolicyTagPrefix + policyID
+}
+
+// SnapshotPolicyRunTag returns the tag set on the snapshots created for the scheduled run
+func SnapshotPolicyRunTag(run time.Time) string {
+	return snapshotPolicyRunTagPrefix + run.UTC().Format(snapshotPolicyRunTagLayout)
+}
+
+// snapshotPolicyRunTag returns the run tag of a snapshot, or an empty string for snapshots created without one
+func snapshotPolicyRunTag(snapshot *SnapshotPolicySnapshot) string {
+	for _, tag := range snapshot.Tags {
+		if strings.HasPrefix(tag, snapshotPolicyRunTagPrefix) {
+			return tag
+		}
+	}
+
+	return ""
+}
+
+// SnapshotPolicyUserTags returns the tags of a snapshot that were not set by the policy to track it
+func SnapshotPolicyUserTags(snapshot *SnapshotPolicySnapshot) []string {
+	tags := []string(nil)
+
+	for _, tag := range snapshot.Tags {
+		if !strings.HasPrefix(tag, snapshotPolicyTagPrefix) && !strings.HasPrefix(tag, snapshotPolicyRunTagPrefix) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// SnapshotPolicyRunVolumeIDs returns the IDs of the volumes already snapshotted for the run,
+// so that an interrupted run is resumed instead of snapshotting the volumes twice
+func SnapshotPolicyRunVolumeIDs(snapshots []*SnapshotPolicySnapshot, runTag string) map[string]struct{} {
+	volumeIDs := make(map[string]struct{})
+
+	for _, snapshot := range snapshots {
+		if snapshotPolicyRunTag(snapshot) == runTag {
+			volumeIDs[snapshot.VolumeID] = struct{}{}
+		}
+	}
+
+	return volumeIDs
+}
+
+// SnapshotPolicyLastRunSnapshots returns the snapshots created by the most recent run of the policy,
+// they are the ones sharing the run tag of the most recent snapshot
+func SnapshotPolicyLastRunSnapshots(snapshots []*SnapshotPolicySnapshot) []*SnapshotPolicySnapshot {
+	var lastSnapshot *SnapshotPolicySnapshot
+
+	for _, snapshot := range snapshots {
+		if lastSnapshot == nil || snapshot.CreatedAt.After(lastSnapshot.CreatedAt) {
+			lastSnapshot = snapshot
+		}
+	}
+
+	if lastSnapshot == nil {
+		return nil
+	}
+
+	lastRunTag := snapshotPolicyRunTag(lastSnapshot)
+	lastRunSnapshots := []*SnapshotPolicySnapshot(nil)
+
+	for _, snapshot := range snapshots {
+		if snapshotPolicyRunTag(snapshot) == lastRunTag {
+			lastRunSnapshots = append(lastRunSnapshots, snapshot)
+		}
+	}
+
+	return lastRunSnapshots
+}
+
+// IsSnapshotPolicyRunDue returns true if a scheduled run happened between lastRun and now.
+// A policy that never ran is always due.
+func IsSnapshotPolicyRunDue(schedule string, lastRun time.Time, now time.Time) (bool, error) {
+	if lastRun.IsZero() {
+		return true, nil
+	}
+
+	nextRun, err := SnapshotPolicyNextRun(schedule, lastRun)
+	if err != nil {
+		return false, err
+	}
+
+	return !nextRun.After(now), nil
+}
+
+// SnapshotPolicyNextRun returns the first scheduled run after the given time
+func SnapshotPolicyNextRun(schedule string, after time.Time) (time.Time, error) {
+	cronSchedule, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+
+	return cronSchedule.Next(after), nil
+}
+
+// SnapshotPolicyPreviousRun returns the last scheduled run at or before the given time
+func SnapshotPolicyPreviousRun(schedule string, before time.Time) (time.Time, error) {
+	cronSchedule, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+
+	// The cron library only looks forward, the run is searched in growing windows to keep frequent schedules cheap
+	for _, window := range []time.Duration{time.Hour, 24 * time.Hour, 32 * 24 * time.Hour, 367 * 24 * time.Hour, 5 * 366 * 24 * time.Hour} {
+		run := cronSchedule.Next(before.Add(-window))
+		if run.IsZero() || run.After(before) {
+			continue
+		}
+
+		for next := cronSchedule.Next(run); !next.IsZero() && !next.After(before); next = cronSchedule.Next(next) {
+			run = next
+		}
+
+		return run, nil
+	}
+
+	return time.Time{}, fmt.Errorf("schedule %q has no run before %s", schedule, before.Format(time.RFC3339))
+}
+
+// SnapshotPolicyName renders a snapshot name template.
+// Supported placeholders are {policy}, {volume_id}, {volume_name}, {date} and {timestamp}.
+func SnapshotPolicyName(template string, policyName string, volumeID string, volumeName string, now time.Time) string {
+	return strings.NewReplacer(
+		"{policy}", policyName,
+		"{volume_id}", volumeID,
+		"{volume_name}", volumeName,
+		"{date}", now.UTC().Format("2006-01-02"),
+		"{timestamp}", now.UTC().Format("20060102-150405"),
+	).Replace(template)
+}
+
+// SnapshotsToPrune returns the snapshots that exceed the retention policy.
+// Snapshots are grouped by volume, the retentionCount most recent snapshots of each volume are kept
+// and snapshots older than retentionDays are pruned. A zero value disables the associated rule.
+func SnapshotsToPrune(snapshots []*SnapshotPolicySnapshot, retentionCount int, retentionDays int, now time.Time) []*SnapshotPolicySnapshot {
+	snapshotsByVolume := make(map[string][]*SnapshotPolicySnapshot)
+	for _, snapshot := range snapshots {
+		snapshotsByVolume[snapshot.VolumeID] = append(snapshotsByVolume[snapshot.VolumeID], snapshot)
+	}
+
+	volumeIDs := make([]string, 0, len(snapshotsByVolume))
+	for volumeID := range snapshotsByVolume {
+		volumeIDs = append(volumeIDs, volumeID)
+	}
+
+	sort.Strings(volumeIDs)
+
+	toPrune := []*SnapshotPolicySnapshot(nil)
+	maxAge := time.Duration(retentionDays) * 24 * time.Hour
+
+	for _, volumeID := range volumeIDs {
+		volumeSnapshots := snapshotsByVolume[volumeID]
+		// Most recent first
+		sort.SliceStable(volumeSnapshots, func(i, j int) bool {
+			return volumeSnapshots[i].CreatedAt.After(volumeSnapshots[j].CreatedAt)
+		})
+
+		for i, snapshot := range volumeSnapshots {
+			exceedsCount := retentionCount > 0 && i >= retentionCount
+			exceedsAge := retentionDays > 0 && now.Sub(snapshot.CreatedAt) > maxAge
+
+			if exceedsCount || exceedsAge {
+				toPrune = append(toPrune, snapshot)
+			}
+		}
+	}
+
+	return toPrune
+}
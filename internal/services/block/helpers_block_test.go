@@ -0,0 +1,167 @@
+package block_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSnapshotPolicyRunDue(t *testing.T) {
+	lastRun := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule string
+		lastRun  time.Time
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "neverRun",
+			schedule: "0 3 * * *",
+			now:      lastRun,
+			expected: true,
+		},
+		{
+			name:     "notDue",
+			schedule: "0 3 * * *",
+			lastRun:  lastRun,
+			now:      lastRun.Add(23 * time.Hour),
+			expected: false,
+		},
+		{
+			name:     "due",
+			schedule: "0 3 * * *",
+			lastRun:  lastRun,
+			now:      lastRun.Add(24 * time.Hour),
+			expected: true,
+		},
+		{
+			name:     "missedRuns",
+			schedule: "0 * * * *",
+			lastRun:  lastRun,
+			now:      lastRun.Add(72 * time.Hour),
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isDue, err := block.IsSnapshotPolicyRunDue(tt.schedule, tt.lastRun, tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, isDue)
+		})
+	}
+
+	_, err := block.IsSnapshotPolicyRunDue("not a cron", lastRun, lastRun)
+	require.Error(t, err)
+}
+
+func TestSnapshotPolicyName(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, "data-20240102-030405", block.SnapshotPolicyName("{volume_name}-{timestamp}", "daily", "11111111-1111-1111-1111-111111111111", "data", now))
+	assert.Equal(t, "daily-11111111-1111-1111-1111-111111111111-2024-01-02", block.SnapshotPolicyName("{policy}-{volume_id}-{date}", "daily", "11111111-1111-1111-1111-111111111111", "data", now))
+}
+
+func TestSnapshotsToPrune(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	snapshots := []*block.SnapshotPolicySnapshot{
+		{ID: "a1", VolumeID: "a", CreatedAt: now.Add(-1 * day)},
+		{ID: "a3", VolumeID: "a", CreatedAt: now.Add(-3 * day)},
+		{ID: "a2", VolumeID: "a", CreatedAt: now.Add(-2 * day)},
+		{ID: "a9", VolumeID: "a", CreatedAt: now.Add(-9 * day)},
+		{ID: "b1", VolumeID: "b", CreatedAt: now.Add(-1 * day)},
+		{ID: "b8", VolumeID: "b", CreatedAt: now.Add(-8 * day)},
+	}
+
+	snapshotIDs := func(snapshots []*block.SnapshotPolicySnapshot) []string {
+		ids := []string(nil)
+		for _, snapshot := range snapshots {
+			ids = append(ids, snapshot.ID)
+		}
+
+		return ids
+	}
+
+	assert.Equal(t, []string{"a3", "a9"}, snapshotIDs(block.SnapshotsToPrune(snapshots, 2, 0, now)))
+	assert.Equal(t, []string{"a9", "b8"}, snapshotIDs(block.SnapshotsToPrune(snapshots, 0, 7, now)))
+	assert.Equal(t, []string{"a2", "a3", "a9", "b8"}, snapshotIDs(block.SnapshotsToPrune(snapshots, 1, 7, now)))
+	assert.Empty(t, block.SnapshotsToPrune(snapshots, 0, 0, now))
+}
+
+func TestSnapshotPolicyPreviousRun(t *testing.T) {
+	now := time.Date(2024, 3, 10, 5, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule string
+		expected time.Time
+	}{
+		{
+			name:     "hourly",
+			schedule: "0 * * * *",
+			expected: time.Date(2024, 3, 10, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily",
+			schedule: "0 3 * * *",
+			expected: time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "yesterday",
+			schedule: "0 6 * * *",
+			expected: time.Date(2024, 3, 9, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "yearly",
+			schedule: "0 0 1 1 *",
+			expected: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "exactlyNow",
+			schedule: "30 5 * * *",
+			expected: now,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run, err := block.SnapshotPolicyPreviousRun(tt.schedule, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, run)
+		})
+	}
+
+	_, err := block.SnapshotPolicyPreviousRun("not a cron", now)
+	require.Error(t, err)
+}
+
+func TestSnapshotPolicyRuns(t *testing.T) {
+	firstRun := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+	secondRun := firstRun.Add(24 * time.Hour)
+	policyTag := block.SnapshotPolicyTag("11111111-1111-1111-1111-111111111111")
+
+	snapshots := []*block.SnapshotPolicySnapshot{
+		{ID: "a1", VolumeID: "a", CreatedAt: firstRun.Add(time.Minute), Tags: []string{"backup", policyTag, block.SnapshotPolicyRunTag(firstRun)}},
+		{ID: "b1", VolumeID: "b", CreatedAt: firstRun.Add(2 * time.Minute), Tags: []string{"backup", policyTag, block.SnapshotPolicyRunTag(firstRun)}},
+		{ID: "a2", VolumeID: "a", CreatedAt: secondRun.Add(time.Minute), Tags: []string{"backup", policyTag, block.SnapshotPolicyRunTag(secondRun)}},
+	}
+
+	// The second run was interrupted after the snapshot of volume a
+	assert.Equal(t, map[string]struct{}{"a": {}}, block.SnapshotPolicyRunVolumeIDs(snapshots, block.SnapshotPolicyRunTag(secondRun)))
+	assert.Empty(t, block.SnapshotPolicyRunVolumeIDs(snapshots, block.SnapshotPolicyRunTag(secondRun.Add(24*time.Hour))))
+
+	lastRunSnapshots := block.SnapshotPolicyLastRunSnapshots(snapshots)
+	require.Len(t, lastRunSnapshots, 1)
+	assert.Equal(t, "a2", lastRunSnapshots[0].ID)
+	assert.Equal(t, []string{"backup"}, block.SnapshotPolicyUserTags(lastRunSnapshots[0]))
+
+	assert.Len(t, block.SnapshotPolicyLastRunSnapshots(snapshots[:2]), 2)
+	assert.Empty(t, block.SnapshotPolicyLastRunSnapshots(nil))
+}
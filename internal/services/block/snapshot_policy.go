@@ -0,0 +1,598 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/instancehelpers"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceSnapshotPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceBlockSnapshotPolicyCreate,
+		ReadContext:   ResourceBlockSnapshotPolicyRead,
+		UpdateContext: ResourceBlockSnapshotPolicyUpdate,
+		DeleteContext: ResourceBlockSnapshotPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBlockSnapshotPolicyImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultSnapshotPolicyTimeout),
+			Update:  schema.DefaultTimeout(defaultSnapshotPolicyTimeout),
+			Default: schema.DefaultTimeout(defaultBlockTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Optional:    true,
+				Description: "The snapshot policy name",
+			},
+			"schedule": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: verify.ValidateCronExpression(),
+				Description:      "Cron expression of the snapshot schedule, evaluated in UTC",
+			},
+			"volume_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+					DiffSuppressFunc: dsf.Locality,
+				},
+				Optional:     true,
+				AtLeastOneOf: []string{"volume_ids", "volume_tags"},
+				Description:  "IDs of the block or instance volumes to snapshot",
+			},
+			"volume_tags": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:     true,
+				AtLeastOneOf: []string{"volume_ids", "volume_tags"},
+				Description:  "Snapshot every volume of the project having all these tags",
+			},
+			"retention_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				AtLeastOneOf: []string{"retention_count", "retention_days"},
+				Description:  "Number of snapshots to keep per volume",
+			},
+			"retention_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				AtLeastOneOf: []string{"retention_count", "retention_days"},
+				Description:  "Number of days to keep snapshots",
+			},
+			"name_template": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     defaultSnapshotPolicyNameTemplate,
+				Description: "Template of the snapshot names, supports {policy}, {volume_id}, {volume_name}, {date} and {timestamp}",
+			},
+			"tags": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "The tags associated with the created snapshots",
+			},
+			"target_volume_ids": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "IDs of the volumes snapshotted during the last run",
+			},
+			"snapshot_ids": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "IDs of the snapshots managed by the policy",
+			},
+			"last_run": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date of the last run of the policy (RFC 3339 format)",
+			},
+			"next_run": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date of the next scheduled run of the policy (RFC 3339 format)",
+			},
+			"zone":       zonal.Schema(),
+			"project_id": account.ProjectIDSchema(),
+		},
+		CustomizeDiff: customDiffSnapshotPolicyRun,
+	}
+}
+
+func ResourceBlockSnapshotPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone, err := meta.ExtractZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policyID, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	projectID, _, err := meta.ExtractProjectID(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("name", types.ExpandOrGenerateString(d.Get("name").(string), "snapshot-policy"))
+	_ = d.Set("project_id", projectID)
+
+	d.SetId(zonal.NewIDString(zone, policyID))
+
+	err = reconcileSnapshotPolicy(ctx, d, m, zone, policyID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceBlockSnapshotPolicyRead(ctx, d, m)
+}
+
+func ResourceBlockSnapshotPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api, zone, policyID, err := instancehelpers.InstanceAndBlockAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The policy only exists in the state, an imported policy belongs to the default project
+	projectID, _, err := meta.ExtractProjectID(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshots, err := listSnapshotPolicySnapshots(ctx, api, zone, projectID, policyID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("snapshot_ids", flattenSnapshotPolicySnapshotIDs(zone, snapshots))
+	_ = d.Set("zone", zone.String())
+	_ = d.Set("project_id", projectID)
+
+	if lastRun, ok := d.GetOk("last_run"); ok && d.Get("schedule").(string) != "" {
+		lastRunTime, err := time.Parse(time.RFC3339, lastRun.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		nextRun, err := SnapshotPolicyNextRun(d.Get("schedule").(string), lastRunTime)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_ = d.Set("next_run", nextRun.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func ResourceBlockSnapshotPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone, policyID, err := zonal.ParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("name") {
+		_ = d.Set("name", types.ExpandOrGenerateString(d.Get("name").(string), "snapshot-policy"))
+	}
+
+	err = reconcileSnapshotPolicy(ctx, d, m, zone, policyID, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceBlockSnapshotPolicyRead(ctx, d, m)
+}
+
+// resourceBlockSnapshotPolicyImport rebuilds the last run of the policy from the tags of its snapshots,
+// so that the import does not trigger a run that already happened
+func resourceBlockSnapshotPolicyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	api, zone, policyID, err := instancehelpers.InstanceAndBlockAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	projectID, _, err := meta.ExtractProjectID(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := listSnapshotPolicySnapshots(ctx, api, zone, projectID, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastRunSnapshots := SnapshotPolicyLastRunSnapshots(snapshots)
+	if len(lastRunSnapshots) == 0 {
+		return nil, fmt.Errorf("no snapshot tagged %s found in project %s", SnapshotPolicyTag(policyID), projectID)
+	}
+
+	lastRun := time.Time{}
+	targetVolumeIDs := []string(nil)
+
+	for _, snapshot := range lastRunSnapshots {
+		if snapshot.CreatedAt.After(lastRun) {
+			lastRun = snapshot.CreatedAt
+		}
+
+		targetVolumeIDs = append(targetVolumeIDs, zonal.NewIDString(zone, snapshot.VolumeID))
+	}
+
+	sort.Strings(targetVolumeIDs)
+
+	_ = d.Set("last_run", lastRun.UTC().Format(time.RFC3339))
+	_ = d.Set("target_volume_ids", targetVolumeIDs)
+	_ = d.Set("tags", SnapshotPolicyUserTags(lastRunSnapshots[0]))
+	_ = d.Set("project_id", projectID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// ResourceBlockSnapshotPolicyDelete only removes the policy from the state, snapshots it created are kept
+func ResourceBlockSnapshotPolicyDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+// customDiffSnapshotPolicyRun plans a run of the policy when a scheduled run has been missed since the last apply
+func customDiffSnapshotPolicyRun(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.NewValueKnown("schedule") {
+		return nil
+	}
+
+	lastRun := time.Time{}
+
+	if rawLastRun := diff.Get("last_run").(string); rawLastRun != "" {
+		parsedLastRun, err := time.Parse(time.RFC3339, rawLastRun)
+		if err != nil {
+			return err
+		}
+
+		lastRun = parsedLastRun
+	}
+
+	isDue, err := IsSnapshotPolicyRunDue(diff.Get("schedule").(string), lastRun, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if !isDue {
+		return nil
+	}
+
+	for _, key := range []string{"snapshot_ids", "target_volume_ids", "last_run", "next_run"} {
+		err = diff.SetNewComputed(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileSnapshotPolicy creates the snapshots of a missed scheduled run and prunes the ones exceeding the retention
+func reconcileSnapshotPolicy(ctx context.Context, d *schema.ResourceData, m interface{}, zone scw.Zone, policyID string, timeout time.Duration) error {
+	api := instancehelpers.NewBlockAndInstanceAPI(meta.ExtractScwClient(m))
+	projectID := d.Get("project_id").(string)
+	now := time.Now().UTC()
+
+	lastRun := time.Time{}
+
+	if rawLastRun, ok := d.GetOk("last_run"); ok {
+		parsedLastRun, err := time.Parse(time.RFC3339, rawLastRun.(string))
+		if err != nil {
+			return err
+		}
+
+		lastRun = parsedLastRun
+	}
+
+	isDue, err := IsSnapshotPolicyRunDue(d.Get("schedule").(string), lastRun, now)
+	if err != nil {
+		return err
+	}
+
+	if isDue {
+		volumes, err := resolveSnapshotPolicyVolumes(ctx, api, zone, projectID, d)
+		if err != nil {
+			return err
+		}
+
+		run, err := SnapshotPolicyPreviousRun(d.Get("schedule").(string), now)
+		if err != nil {
+			return err
+		}
+
+		runTag := SnapshotPolicyRunTag(run)
+
+		snapshots, err := listSnapshotPolicySnapshots(ctx, api, zone, projectID, policyID)
+		if err != nil {
+			return err
+		}
+
+		snapshottedVolumeIDs := SnapshotPolicyRunVolumeIDs(snapshots, runTag)
+		targetVolumeIDs := make([]string, 0, len(volumes))
+
+		for _, volume := range volumes {
+			if _, snapshotted := snapshottedVolumeIDs[volume.ID]; snapshotted {
+				tflog.Debug(ctx, fmt.Sprintf("volume %s already has a snapshot for run %s, resuming the run", volume.ID, run.Format(time.RFC3339)))
+			} else {
+				err := createSnapshotPolicySnapshot(ctx, api, d, policyID, runTag, volume, now, timeout)
+				if err != nil {
+					return err
+				}
+			}
+
+			targetVolumeIDs = append(targetVolumeIDs, zonal.NewIDString(zone, volume.ID))
+		}
+
+		_ = d.Set("target_volume_ids", targetVolumeIDs)
+		_ = d.Set("last_run", now.Format(time.RFC3339))
+	}
+
+	snapshots, err := listSnapshotPolicySnapshots(ctx, api, zone, projectID, policyID)
+	if err != nil {
+		return err
+	}
+
+	toPrune := SnapshotsToPrune(snapshots, d.Get("retention_count").(int), d.Get("retention_days").(int), now)
+	for _, snapshot := range toPrune {
+		tflog.Debug(ctx, fmt.Sprintf("pruning snapshot %s of volume %s", snapshot.ID, snapshot.VolumeID))
+
+		err := deleteSnapshotPolicySnapshot(ctx, api, zone, snapshot, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveSnapshotPolicyVolumes returns the volumes targeted by volume_ids and volume_tags, sorted by ID
+func resolveSnapshotPolicyVolumes(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, projectID string, d *schema.ResourceData) ([]*instancehelpers.UnknownVolume, error) {
+	volumes := make(map[string]*instancehelpers.UnknownVolume)
+
+	for _, rawVolumeID := range d.Get("volume_ids").(*schema.Set).List() {
+		volume, err := api.GetUnknownVolume(&instancehelpers.GetUnknownVolumeRequest{
+			VolumeID: locality.ExpandID(rawVolumeID),
+			Zone:     zone,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get volume %s: %w", rawVolumeID, err)
+		}
+
+		volumes[volume.ID] = volume
+	}
+
+	if volumeTags := types.ExpandStrings(d.Get("volume_tags")); len(volumeTags) > 0 {
+		blockVolumes, err := api.BlockAPI.ListVolumes(&block.ListVolumesRequest{
+			Zone:      zone,
+			ProjectID: types.ExpandStringPtr(projectID),
+			Tags:      volumeTags,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, volume := range blockVolumes.Volumes {
+			volumes[volume.ID] = &instancehelpers.UnknownVolume{
+				Zone:               volume.Zone,
+				ID:                 volume.ID,
+				Name:               volume.Name,
+				InstanceVolumeType: instance.VolumeVolumeTypeSbsVolume,
+			}
+		}
+
+		instanceVolumes, err := api.ListVolumes(&instance.ListVolumesRequest{
+			Zone:    zone,
+			Project: types.ExpandStringPtr(projectID),
+			Tags:    volumeTags,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, volume := range instanceVolumes.Volumes {
+			volumes[volume.ID] = &instancehelpers.UnknownVolume{
+				Zone:               volume.Zone,
+				ID:                 volume.ID,
+				Name:               volume.Name,
+				InstanceVolumeType: volume.VolumeType,
+			}
+		}
+	}
+
+	sortedVolumes := make([]*instancehelpers.UnknownVolume, 0, len(volumes))
+	for _, volume := range volumes {
+		sortedVolumes = append(sortedVolumes, volume)
+	}
+
+	sort.Slice(sortedVolumes, func(i, j int) bool {
+		return sortedVolumes[i].ID < sortedVolumes[j].ID
+	})
+
+	return sortedVolumes, nil
+}
+
+func createSnapshotPolicySnapshot(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, d *schema.ResourceData, policyID string, runTag string, volume *instancehelpers.UnknownVolume, now time.Time, timeout time.Duration) error {
+	name := SnapshotPolicyName(d.Get("name_template").(string), d.Get("name").(string), volume.ID, volume.Name, now)
+	tags := append(types.ExpandStrings(d.Get("tags")), SnapshotPolicyTag(policyID), runTag)
+
+	tflog.Debug(ctx, fmt.Sprintf("creating snapshot %q of volume %s", name, volume.ID))
+
+	if volume.IsBlockVolume() {
+		snapshot, err := api.BlockAPI.CreateSnapshot(&block.CreateSnapshotRequest{
+			Zone:      volume.Zone,
+			ProjectID: d.Get("project_id").(string),
+			Name:      name,
+			VolumeID:  volume.ID,
+			Tags:      tags,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to create snapshot of volume %s: %w", volume.ID, err)
+		}
+
+		_, err = waitForBlockSnapshotToBeAvailable(ctx, api.BlockAPI, volume.Zone, snapshot.ID, timeout)
+
+		return err
+	}
+
+	res, err := api.CreateSnapshot(&instance.CreateSnapshotRequest{
+		Zone:     volume.Zone,
+		Name:     name,
+		VolumeID: &volume.ID,
+		Tags:     &tags,
+		Project:  types.ExpandStringPtr(d.Get("project_id")),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot of volume %s: %w", volume.ID, err)
+	}
+
+	_, err = waitForInstanceSnapshot(ctx, api.API, volume.Zone, res.Snapshot.ID, timeout)
+
+	return err
+}
+
+func deleteSnapshotPolicySnapshot(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, snapshot *SnapshotPolicySnapshot, timeout time.Duration) error {
+	if snapshot.IsBlock {
+		_, err := waitForBlockSnapshotToBeAvailable(ctx, api.BlockAPI, zone, snapshot.ID, timeout)
+		if err != nil {
+			return err
+		}
+
+		err = api.BlockAPI.DeleteSnapshot(&block.DeleteSnapshotRequest{
+			Zone:       zone,
+			SnapshotID: snapshot.ID,
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			return fmt.Errorf("failed to delete snapshot %s: %w", snapshot.ID, err)
+		}
+
+		return nil
+	}
+
+	_, err := waitForInstanceSnapshot(ctx, api.API, zone, snapshot.ID, timeout)
+	if err != nil {
+		return err
+	}
+
+	err = api.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+		Zone:       zone,
+		SnapshotID: snapshot.ID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return fmt.Errorf("failed to delete snapshot %s: %w", snapshot.ID, err)
+	}
+
+	return nil
+}
+
+// listSnapshotPolicySnapshots lists the block and instance snapshots tagged by the policy
+func listSnapshotPolicySnapshots(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, projectID string, policyID string) ([]*SnapshotPolicySnapshot, error) {
+	policyTag := SnapshotPolicyTag(policyID)
+	snapshots := []*SnapshotPolicySnapshot(nil)
+
+	blockSnapshots, err := api.BlockAPI.ListSnapshots(&block.ListSnapshotsRequest{
+		Zone:      zone,
+		ProjectID: types.ExpandStringPtr(projectID),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshot := range blockSnapshots.Snapshots {
+		if !types.SliceContainsString(snapshot.Tags, policyTag) || snapshot.CreatedAt == nil {
+			continue
+		}
+
+		policySnapshot := &SnapshotPolicySnapshot{
+			ID:        snapshot.ID,
+			CreatedAt: *snapshot.CreatedAt,
+			Tags:      snapshot.Tags,
+			IsBlock:   true,
+		}
+		if snapshot.ParentVolume != nil {
+			policySnapshot.VolumeID = snapshot.ParentVolume.ID
+		}
+
+		snapshots = append(snapshots, policySnapshot)
+	}
+
+	instanceSnapshots, err := api.ListSnapshots(&instance.ListSnapshotsRequest{
+		Zone:    zone,
+		Project: types.ExpandStringPtr(projectID),
+		Tags:    &policyTag,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshot := range instanceSnapshots.Snapshots {
+		if !types.SliceContainsString(snapshot.Tags, policyTag) || snapshot.CreationDate == nil {
+			continue
+		}
+
+		policySnapshot := &SnapshotPolicySnapshot{
+			ID:        snapshot.ID,
+			CreatedAt: *snapshot.CreationDate,
+			Tags:      snapshot.Tags,
+		}
+		if snapshot.BaseVolume != nil {
+			policySnapshot.VolumeID = snapshot.BaseVolume.ID
+		}
+
+		snapshots = append(snapshots, policySnapshot)
+	}
+
+	return snapshots, nil
+}
+
+func flattenSnapshotPolicySnapshotIDs(zone scw.Zone, snapshots []*SnapshotPolicySnapshot) []string {
+	sortedSnapshots := append([]*SnapshotPolicySnapshot(nil), snapshots...)
+	sort.SliceStable(sortedSnapshots, func(i, j int) bool {
+		return sortedSnapshots[i].CreatedAt.Before(sortedSnapshots[j].CreatedAt)
+	})
+
+	snapshotIDs := make([]string, 0, len(sortedSnapshots))
+	for _, snapshot := range sortedSnapshots {
+		snapshotIDs = append(snapshotIDs, zonal.NewIDString(zone, snapshot.ID))
+	}
+
+	return snapshotIDs
+}
@@ -0,0 +1,86 @@
+package block_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	blocktestfuncs "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/block/testfuncs"
+)
+
+func TestAccSnapshotPolicy_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	// A yearly schedule only runs on creation, later plans do not depend on the date the cassette is replayed
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      blocktestfuncs.IsVolumeDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource scaleway_block_volume main {
+						name = "test-block-snapshot-policy-basic"
+						iops = 5000
+						size_in_gb = 10
+					}
+
+					resource scaleway_block_snapshot_policy main {
+						name = "test-block-snapshot-policy-basic"
+						schedule = "0 0 1 1 *"
+						volume_ids = [scaleway_block_volume.main.id]
+						retention_count = 1
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_block_snapshot_policy.main", "snapshot_ids.#", "1"),
+					resource.TestCheckResourceAttr("scaleway_block_snapshot_policy.main", "target_volume_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("scaleway_block_snapshot_policy.main", "target_volume_ids.0", "scaleway_block_volume.main", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_block_snapshot_policy.main", "project_id", "scaleway_block_volume.main", "project_id"),
+					resource.TestCheckResourceAttrSet("scaleway_block_snapshot_policy.main", "last_run"),
+					resource.TestCheckResourceAttrSet("scaleway_block_snapshot_policy.main", "next_run"),
+				),
+			},
+			{
+				Config: `
+					resource scaleway_block_volume main {
+						name = "test-block-snapshot-policy-basic"
+						iops = 5000
+						size_in_gb = 10
+					}
+
+					resource scaleway_block_snapshot_policy main {
+						name = "test-block-snapshot-policy-basic"
+						schedule = "0 0 1 1 *"
+						volume_ids = [scaleway_block_volume.main.id]
+						retention_count = 1
+						tags = ["test-terraform"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_block_snapshot_policy.main", "snapshot_ids.#", "1"),
+					resource.TestCheckResourceAttr("scaleway_block_snapshot_policy.main", "tags.0", "test-terraform"),
+				),
+			},
+			{
+				// The last run is rebuilt from the tags of the snapshots, the snapshot of the first run has no user tags
+				ResourceName:      "scaleway_block_snapshot_policy.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"name", "schedule", "volume_ids", "retention_count", "name_template", "tags", "last_run", "next_run",
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["last_run"] == "" {
+						return errors.New("the last run of the policy was not imported")
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
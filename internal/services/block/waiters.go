@@ -5,6 +5,7 @@ import (
 	"time"
 
 	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
 )
@@ -78,3 +79,19 @@ func waitForBlockSnapshotToBeAvailable(ctx context.Context, blockAPI *block.API,
 
 	return snapshot, err
 }
+
+func waitForInstanceSnapshot(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, id string, timeout time.Duration) (*instance.Snapshot, error) {
+	retryInterval := defaultBlockRetryInterval
+	if transport.DefaultWaitRetryInterval != nil {
+		retryInterval = *transport.DefaultWaitRetryInterval
+	}
+
+	snapshot, err := instanceAPI.WaitForSnapshot(&instance.WaitForSnapshotRequest{
+		Zone:          zone,
+		SnapshotID:    id,
+		RetryInterval: &retryInterval,
+		Timeout:       scw.TimeDurationPtr(timeout),
+	}, scw.WithContext(ctx))
+
+	return snapshot, err
+}
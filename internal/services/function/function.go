@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
@@ -120,9 +121,10 @@ func ResourceFunction() *schema.Resource {
 				Optional:    true,
 			},
 			"zip_file": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Location of the zip file to upload containing your function sources",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Location of the zip file to upload containing your function sources",
+				ConflictsWith: []string{"source_dir"},
 			},
 			"zip_hash": {
 				Type:         schema.TypeString,
@@ -130,6 +132,26 @@ func ResourceFunction() *schema.Resource {
 				RequiredWith: []string{"zip_file"},
 				Description:  "The hash of your source zip file, changing it will re-apply function. Can be any string",
 			},
+			"source_dir": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Directory containing your function sources, zipped and uploaded when its content changes",
+				ConflictsWith: []string{"zip_file"},
+			},
+			"excludes": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Description:  "Patterns of the files of source_dir to exclude from the zip, with the .dockerignore syntax",
+				RequiredWith: []string{"source_dir"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"source_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA256 of the zip built from source_dir",
+			},
 			"deploy": {
 				Type:        schema.TypeBool,
 				Default:     false,
@@ -164,7 +186,10 @@ func ResourceFunction() *schema.Resource {
 			"organization_id": account.OrganizationIDSchema(),
 			"project_id":      account.ProjectIDSchema(),
 		},
-		CustomizeDiff: cdf.LocalityCheck("namespace_id"),
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("namespace_id"),
+			customizeDiffFunctionSourceSHA256,
+		),
 	}
 }
 
@@ -218,6 +243,17 @@ func ResourceFunctionCreate(ctx context.Context, d *schema.ResourceData, m inter
 		}
 	}
 
+	if sourceDir, sourceDirExists := d.GetOk("source_dir"); sourceDirExists {
+		err = functionUploadSourceDir(ctx, m, api, region, f.ID, sourceDir.(string), types.ExpandStrings(d.Get("excludes")))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to upload function",
+				Detail:   err.Error(),
+			})
+		}
+	}
+
 	if d.Get("deploy").(bool) {
 		err = functionDeploy(ctx, api, region, f.ID)
 		if err != nil {
@@ -400,11 +436,23 @@ func ResourceFunctionUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		time.Sleep(defaultFunctionAfterUpdateWait)
 	}
 
-	zipHasChanged := d.HasChanges("zip_hash", "zip_file")
+	zipHasChanged := d.HasChanges("zip_hash", "zip_file", "source_dir", "excludes", "source_sha256")
 	shouldDeploy := d.Get("deploy").(bool)
 
 	if zipHasChanged {
-		err = functionUpload(ctx, m, api, region, f.ID, d.Get("zip_file").(string))
+		sourceDir, sourceDirExists := d.GetOk("source_dir")
+		zipFile, zipFileExists := d.GetOk("zip_file")
+
+		switch {
+		case sourceDirExists:
+			err = functionUploadSourceDir(ctx, m, api, region, f.ID, sourceDir.(string), types.ExpandStrings(d.Get("excludes")))
+		case zipFileExists:
+			err = functionUpload(ctx, m, api, region, f.ID, zipFile.(string))
+		default:
+			// Without zip_file nor source_dir, the code uploaded last is kept
+			zipHasChanged = false
+		}
+
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("failed to upload function: %w", err))
 		}
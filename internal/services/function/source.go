@@ -0,0 +1,155 @@
+package function
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/fileset"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+)
+
+// sourceZipModTime is the modification time of every zip entry, the oldest date supported by the zip format
+var sourceZipModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ZipSourceDir writes a deterministic zip archive of the directory: entries are sorted, timestamps are fixed
+// and modes are normalized so that the archive only depends on the content of the files
+func ZipSourceDir(sourceDir string, excludes []string, w io.Writer) error {
+	var paths []string
+
+	err := fileset.Walk(sourceDir, excludes, func(relPath string, entry fs.DirEntry) error {
+		if !entry.IsDir() {
+			paths = append(paths, relPath)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read source directory %s: %w", sourceDir, err)
+	}
+
+	sort.Strings(paths)
+
+	zipWriter := zip.NewWriter(w)
+
+	for _, relPath := range paths {
+		err = addFileToZip(zipWriter, filepath.Join(sourceDir, filepath.FromSlash(relPath)), relPath)
+		if err != nil {
+			return fmt.Errorf("failed to archive %s: %w", relPath, err)
+		}
+	}
+
+	return zipWriter.Close()
+}
+
+func addFileToZip(zipWriter *zip.Writer, path string, name string) error {
+	// Symbolic links are followed
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a regular file, symbolic links to directories are not supported", name)
+	}
+
+	mode := os.FileMode(0o644)
+	if info.Mode()&0o111 != 0 {
+		mode = 0o755
+	}
+
+	header := &zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: sourceZipModTime,
+	}
+	header.SetMode(mode)
+
+	entryWriter, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(entryWriter, file)
+
+	return err
+}
+
+// sourceDirSHA256 returns the hash of the zip archive of the source directory
+func sourceDirSHA256(sourceDir string, excludes []string) (string, error) {
+	hash := sha256.New()
+
+	err := ZipSourceDir(sourceDir, excludes, hash)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// functionUploadSourceDir zips the source directory in a temporary file and uploads it
+func functionUploadSourceDir(ctx context.Context, m interface{}, functionAPI *function.API, region scw.Region, functionID string, sourceDir string, excludes []string) error {
+	zipFile, err := os.CreateTemp("", "scaleway-function-*.zip")
+	if err != nil {
+		return fmt.Errorf("failed to create zip file: %w", err)
+	}
+	defer os.Remove(zipFile.Name())
+
+	err = ZipSourceDir(sourceDir, excludes, zipFile)
+	if err != nil {
+		zipFile.Close()
+
+		return err
+	}
+
+	err = zipFile.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write zip file: %w", err)
+	}
+
+	return functionUpload(ctx, m, functionAPI, region, functionID, zipFile.Name())
+}
+
+// customizeDiffFunctionSourceSHA256 hashes the source directory, a new hash uploads the function again
+func customizeDiffFunctionSourceSHA256(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	sourceDir, ok := diff.GetOk("source_dir")
+	if !ok {
+		if diff.Get("source_sha256").(string) != "" {
+			return diff.SetNew("source_sha256", "")
+		}
+
+		return nil
+	}
+
+	if !diff.NewValueKnown("source_dir") || !diff.NewValueKnown("excludes") {
+		return diff.SetNewComputed("source_sha256")
+	}
+
+	sourceSHA256, err := sourceDirSHA256(sourceDir.(string), types.ExpandStrings(diff.Get("excludes")))
+	if err != nil {
+		return err
+	}
+
+	if diff.Get("source_sha256").(string) == sourceSHA256 {
+		return nil
+	}
+
+	return diff.SetNew("source_sha256", sourceSHA256)
+}
@@ -0,0 +1,84 @@
+package function_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/function"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipSourceDir(t *testing.T) {
+	sourceDir := t.TempDir()
+
+	files := map[string]string{
+		"handler.js":                "module.exports.handle = () => {}",
+		"lib/util.js":               "module.exports = {}",
+		"README.md":                 "# function",
+		"node_modules/dep/index.js": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(sourceDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	require.NoError(t, os.Chmod(filepath.Join(sourceDir, "lib", "util.js"), 0o700))
+
+	excludes := []string{"*.md", "node_modules", "!node_modules/dep"}
+
+	first := &bytes.Buffer{}
+	require.NoError(t, function.ZipSourceDir(sourceDir, excludes, first))
+
+	// Touching files must not change the archive
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(sourceDir, "handler.js"), later, later))
+
+	second := &bytes.Buffer{}
+	require.NoError(t, function.ZipSourceDir(sourceDir, excludes, second))
+	assert.Equal(t, first.Bytes(), second.Bytes())
+
+	reader, err := zip.NewReader(bytes.NewReader(first.Bytes()), int64(first.Len()))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(reader.File))
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+
+	assert.Equal(t, []string{"handler.js", "lib/util.js", "node_modules/dep/index.js"}, names)
+	assert.Equal(t, os.FileMode(0o644), reader.File[0].Mode().Perm())
+	assert.Equal(t, os.FileMode(0o755), reader.File[1].Mode().Perm())
+
+	assert.Error(t, function.ZipSourceDir(sourceDir, []string{"["}, &bytes.Buffer{}))
+}
+
+func TestZipSourceDir_Symlinks(t *testing.T) {
+	sourceDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(sourceDir, "lib"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "lib", "util.js"), []byte("module.exports = {}"), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(sourceDir, "lib", "util.js"), filepath.Join(sourceDir, "util.js")))
+
+	// Symbolic links to files are followed
+	archive := &bytes.Buffer{}
+	require.NoError(t, function.ZipSourceDir(sourceDir, nil, archive))
+
+	reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	require.NoError(t, err)
+	require.Len(t, reader.File, 2)
+	assert.Equal(t, "util.js", reader.File[1].Name)
+
+	// Symbolic links to directories are reported, unless excluded
+	require.NoError(t, os.Symlink(filepath.Join(sourceDir, "lib"), filepath.Join(sourceDir, "vendor")))
+
+	err = function.ZipSourceDir(sourceDir, nil, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vendor")
+
+	require.NoError(t, function.ZipSourceDir(sourceDir, []string{"vendor"}, &bytes.Buffer{}))
+}
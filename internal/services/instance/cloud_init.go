@@ -0,0 +1,433 @@
+package instance
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	cloudInitUserDataKey = "cloud-init"
+	// CloudInitMaxSize is the maximum size of a user data value accepted by the instance API
+	CloudInitMaxSize = 127998
+
+	cloudConfigHeader    = "#cloud-config"
+	cloudConfigMediaType = "text/cloud-config"
+)
+
+// cloudInitHeaders are the first line prefixes cloud-init recognizes besides #cloud-config
+var cloudInitHeaders = []string{
+	"#!",
+	"#include",
+	"#include-once",
+	"#cloud-boothook",
+	"#part-handler",
+	"#upstart-job",
+	"## template: jinja",
+}
+
+// cloudInitMediaTypes are the multipart content types handled by cloud-init
+var cloudInitMediaTypes = map[string]struct{}{
+	cloudConfigMediaType:              {},
+	"text/cloud-config-archive":       {},
+	"text/x-shellscript":              {},
+	"text/x-shellscript-per-boot":     {},
+	"text/x-shellscript-per-instance": {},
+	"text/x-shellscript-per-once":     {},
+	"text/x-include-url":              {},
+	"text/x-include-once-url":         {},
+	"text/cloud-boothook":             {},
+	"text/part-handler":               {},
+	"text/upstart-job":                {},
+	"text/jinja2":                     {},
+	"text/plain":                      {},
+}
+
+// cloudConfigKeys are the top-level keys known by cloud-init modules
+var cloudConfigKeys = map[string]struct{}{
+	"ansible": {}, "apk_repos": {}, "apt": {}, "apt_pipelining": {}, "apt_preserve_sources_list": {},
+	"apt_reboot_if_required": {}, "apt_update": {}, "apt_upgrade": {}, "autoinstall": {}, "bootcmd": {},
+	"byobu_by_default": {}, "ca_certs": {}, "ca-certs": {}, "chef": {}, "chpasswd": {}, "cloud_config_modules": {},
+	"cloud_final_modules": {}, "cloud_init_modules": {}, "create_hostname_file": {}, "datasource": {},
+	"device_aliases": {}, "disable_ec2_metadata": {}, "disable_root": {}, "disable_root_opts": {},
+	"disk_setup": {}, "drivers": {}, "fan": {}, "final_message": {}, "fqdn": {}, "fs_setup": {}, "groups": {},
+	"growpart": {}, "hostname": {}, "keyboard": {}, "landscape": {}, "locale": {}, "locale_configfile": {},
+	"lxd": {}, "manage_etc_hosts": {}, "manage_resolv_conf": {}, "mcollective": {}, "merge_how": {},
+	"merge_type": {}, "mount_default_fields": {}, "mounts": {}, "no_ssh_fingerprints": {}, "ntp": {},
+	"output": {}, "package_reboot_if_required": {}, "package_update": {}, "package_upgrade": {},
+	"packages": {}, "password": {}, "phone_home": {}, "power_state": {}, "prefer_fqdn_over_hostname": {},
+	"preserve_hostname": {}, "puppet": {}, "random_seed": {}, "reporting": {}, "resize_rootfs": {},
+	"resolv_conf": {}, "rh_subscription": {}, "rsyslog": {}, "runcmd": {}, "salt_minion": {}, "seed_random": {},
+	"snap": {}, "spacewalk": {}, "ssh": {}, "ssh_authorized_keys": {}, "ssh_deletekeys": {},
+	"ssh_fp_console_blacklist": {}, "ssh_genkeytypes": {}, "ssh_import_id": {}, "ssh_key_console_blacklist": {},
+	"ssh_keys": {}, "ssh_publish_hostkeys": {}, "ssh_pwauth": {}, "ssh_quiet_keygen": {}, "swap": {},
+	"system_info": {}, "timezone": {}, "ubuntu_advantage": {}, "ubuntu_pro": {}, "updates": {},
+	"user": {}, "users": {}, "vendor_data": {}, "wireguard": {}, "write_files": {}, "yum_repo_dir": {},
+	"yum_repos": {}, "zypper": {},
+}
+
+var yamlErrorLineRegexp = regexp.MustCompile(`line (\d+):`)
+
+// ValidateCloudInit checks a cloud-init user data payload.
+// It returns errors for payloads cloud-init would reject and warnings for content that looks suspicious or that cloud-init would skip.
+func ValidateCloudInit(content string, path cty.Path) diag.Diagnostics {
+	if len(content) > CloudInitMaxSize {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("cloud-init payload is too large: %d bytes, maximum is %d", len(content), CloudInitMaxSize),
+			AttributePath: path,
+		}}
+	}
+
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	if isMultipartCloudInit(content) {
+		return validateMultipartCloudInit(content, path)
+	}
+
+	firstLine, _, _ := strings.Cut(content, "\n")
+	firstLine = strings.TrimRight(firstLine, " \t")
+
+	switch {
+	case firstLine == cloudConfigHeader:
+		return validateCloudConfig(content, path, "")
+	case hasCloudInitHeader(firstLine):
+		return nil
+	case strings.HasPrefix(content, "\x1f\x8b"):
+		// gzip compressed payloads are decompressed by cloud-init and cannot be checked here
+		return nil
+	}
+
+	// Images may run another agent than cloud-init that consumes this user data, so it is not rejected
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       "cloud-init payload has no recognized header",
+		Detail:        fmt.Sprintf("line 1: %q: cloud-init ignores user data that does not start with %q, a shebang or another supported header", firstLine, cloudConfigHeader),
+		AttributePath: path,
+	}}
+}
+
+// IsCloudInitPayload returns true if content is explicitly marked as a cloud-config or multipart payload
+func IsCloudInitPayload(content string) bool {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	firstLine, _, _ := strings.Cut(content, "\n")
+
+	return strings.TrimRight(firstLine, " \t") == cloudConfigHeader || isMultipartCloudInit(content)
+}
+
+func hasCloudInitHeader(firstLine string) bool {
+	for _, header := range cloudInitHeaders {
+		if strings.HasPrefix(firstLine, header) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isMultipartCloudInit(content string) bool {
+	reader := bufio.NewScanner(strings.NewReader(content))
+	for reader.Scan() {
+		line := reader.Text()
+		if line == "" {
+			// End of the header section
+			return false
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			return false
+		}
+
+		if strings.EqualFold(name, "Content-Type") && strings.HasPrefix(strings.TrimSpace(strings.ToLower(value)), "multipart/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateMultipartCloudInit(content string, path cty.Path) diag.Diagnostics {
+	msg, err := mail.ReadMessage(strings.NewReader(content))
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid multipart cloud-init payload",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || params["boundary"] == "" {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid multipart cloud-init payload",
+			Detail:        "Content-Type header must define a boundary",
+			AttributePath: path,
+		}}
+	}
+
+	diags := diag.Diagnostics(nil)
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+
+	for partIndex := 1; ; partIndex++ {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			if partIndex == 1 {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Warning,
+					Summary:       "multipart cloud-init payload has no parts",
+					AttributePath: path,
+				})
+			}
+
+			return diags
+		}
+
+		if err != nil {
+			return append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("invalid multipart cloud-init payload at part %d", partIndex),
+				Detail:        err.Error(),
+				AttributePath: path,
+			})
+		}
+
+		partName := fmt.Sprintf("part %d", partIndex)
+		if fileName := part.FileName(); fileName != "" {
+			partName = fmt.Sprintf("part %d (%s)", partIndex, fileName)
+		}
+
+		body, err := readMultipartCloudInitPart(part)
+		if err != nil {
+			return append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "cannot read multipart cloud-init " + partName,
+				Detail:        err.Error(),
+				AttributePath: path,
+			})
+		}
+
+		mediaType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "multipart cloud-init " + partName + " has no valid Content-Type",
+				Detail:        "cloud-init will try to guess the type of this part from its first line",
+				AttributePath: path,
+			})
+
+			continue
+		}
+
+		if _, known := cloudInitMediaTypes[mediaType]; !known {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("multipart cloud-init %s has unsupported Content-Type %q", partName, mediaType),
+				Detail:        "cloud-init will ignore this part",
+				AttributePath: path,
+			})
+
+			continue
+		}
+
+		if mediaType == cloudConfigMediaType {
+			diags = append(diags, validateCloudConfig(body, path, partName)...)
+		}
+	}
+}
+
+func readMultipartCloudInitPart(part *multipart.Part) (string, error) {
+	reader := io.Reader(part)
+	if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "base64") {
+		reader = base64.NewDecoder(base64.StdEncoding, part)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+// validateCloudConfig checks the YAML document of a cloud-config, location is used to identify multipart parts
+func validateCloudConfig(content string, path cty.Path, location string) diag.Diagnostics {
+	at := func(line int) string {
+		if location == "" {
+			return fmt.Sprintf("line %d", line)
+		}
+
+		return fmt.Sprintf("%s, line %d", location, line)
+	}
+
+	document := yaml.Node{}
+
+	err := yaml.Unmarshal([]byte(content), &document)
+	if err != nil {
+		detail := err.Error()
+		summary := "invalid cloud-config YAML"
+
+		if match := yamlErrorLineRegexp.FindStringSubmatch(detail); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			summary = fmt.Sprintf("invalid cloud-config YAML at %s", at(line))
+		}
+
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        detail,
+			AttributePath: path,
+		}}
+	}
+
+	// A cloud-config with only a header is valid
+	if len(document.Content) == 0 {
+		return nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "cloud-config must be a YAML mapping at " + at(root.Line),
+			Detail:        "top-level content of a cloud-config should be a set of key/value pairs such as `packages:` or `runcmd:`",
+			AttributePath: path,
+		}}
+	}
+
+	diags := diag.Diagnostics(nil)
+	seenKeys := make(map[string]int, len(root.Content)/2)
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode := root.Content[i]
+		key := keyNode.Value
+
+		if firstLine, duplicated := seenKeys[key]; duplicated {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("duplicated cloud-config key %q at %s", key, at(keyNode.Line)),
+				Detail:        fmt.Sprintf("this key is already defined at line %d, only the last value will be used", firstLine),
+				AttributePath: path,
+			})
+
+			continue
+		}
+
+		seenKeys[key] = keyNode.Line
+
+		if _, known := cloudConfigKeys[key]; !known {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("unknown cloud-config key %q at %s", key, at(keyNode.Line)),
+				Detail:        "cloud-init will ignore this key, check for typos such as `-` instead of `_`",
+				AttributePath: path,
+			})
+		}
+	}
+
+	return diags
+}
+
+// validateCloudInitUserData is a ValidateDiagFunc for user_data maps, it validates the cloud-init key
+func validateCloudInitUserData() schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		userData, ok := i.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		cloudInit, ok := userData[cloudInitUserDataKey].(string)
+		if !ok {
+			return nil
+		}
+
+		return ValidateCloudInit(cloudInit, path.IndexString(cloudInitUserDataKey))
+	}
+}
+
+// validateCloudInitValue is a ValidateDiagFunc for raw user data values,
+// only payloads explicitly marked as cloud-init are validated as the key is not known at this point.
+func validateCloudInitValue() schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		value, ok := i.(string)
+		if !ok || !IsCloudInitPayload(value) {
+			return nil
+		}
+
+		return ValidateCloudInit(value, path)
+	}
+}
+
+// cloudInitDiagnosticsError returns an error that sums up error diagnostics, warnings are ignored
+func cloudInitDiagnosticsError(attribute string, diags diag.Diagnostics) error {
+	messages := []string(nil)
+
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+
+		message := d.Summary
+		if d.Detail != "" {
+			message += ": " + d.Detail
+		}
+
+		messages = append(messages, message)
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("invalid %s: %s", attribute, strings.Join(messages, "; "))
+}
+
+// customDiffInstanceServerCloudInit validates cloud-init payloads that are only known at plan time
+func customDiffInstanceServerCloudInit(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.HasChange("user_data") && diff.NewValueKnown("user_data") {
+		userData := diff.Get("user_data").(map[string]interface{})
+		if cloudInit, hasCloudInit := userData[cloudInitUserDataKey].(string); hasCloudInit {
+			err := cloudInitDiagnosticsError(`user_data["cloud-init"]`, ValidateCloudInit(cloudInit, nil))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if diff.HasChange("cloud_init") && diff.NewValueKnown("cloud_init") {
+		if cloudInit := diff.Get("cloud_init").(string); cloudInit != "" {
+			return cloudInitDiagnosticsError("cloud_init", ValidateCloudInit(cloudInit, nil))
+		}
+	}
+
+	return nil
+}
+
+// customDiffInstanceUserDataCloudInit validates the value of cloud-init user data
+func customDiffInstanceUserDataCloudInit(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.HasChange("value") || !diff.NewValueKnown("value") || !diff.NewValueKnown("key") {
+		return nil
+	}
+
+	if diff.Get("key").(string) != cloudInitUserDataKey {
+		return nil
+	}
+
+	return cloudInitDiagnosticsError("value", ValidateCloudInit(diff.Get("value").(string), nil))
+}
@@ -0,0 +1,143 @@
+package instance_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCloudInit(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		{
+			name:    "validCloudConfig",
+			content: "#cloud-config\npackage_update: true\npackages:\n  - nginx\n",
+		},
+		{
+			name:    "emptyCloudConfig",
+			content: "#cloud-config\n",
+		},
+		{
+			name:    "shellScript",
+			content: "#!/bin/bash\necho hello\n",
+		},
+		{
+			name:             "missingHeader",
+			content:          "package_update: true\n",
+			expectedWarnings: []string{"cloud-init payload has no recognized header"},
+		},
+		{
+			name:           "invalidYAML",
+			content:        "#cloud-config\npackage_update: true\nruncmd: echo: hello\n",
+			expectedErrors: []string{"invalid cloud-config YAML at line 3"},
+		},
+		{
+			name:           "notAMapping",
+			content:        "#cloud-config\n- nginx\n",
+			expectedErrors: []string{"cloud-config must be a YAML mapping at line 2"},
+		},
+		{
+			name:             "unknownKey",
+			content:          "#cloud-config\napt_update: true\napt-upgrade: true\n",
+			expectedWarnings: []string{`unknown cloud-config key "apt-upgrade" at line 3`},
+		},
+		{
+			name:             "duplicatedKey",
+			content:          "#cloud-config\nruncmd: []\nruncmd: []\n",
+			expectedWarnings: []string{`duplicated cloud-config key "runcmd" at line 3`},
+		},
+		{
+			name:           "tooLarge",
+			content:        "#cloud-config\n" + strings.Repeat("#", instance.CloudInitMaxSize),
+			expectedErrors: []string{"cloud-init payload is too large"},
+		},
+		{
+			name: "multipart",
+			content: `Content-Type: multipart/mixed; boundary="BOUNDARY"
+MIME-Version: 1.0
+
+--BOUNDARY
+Content-Type: text/cloud-config; charset="us-ascii"
+Content-Disposition: attachment; filename="cloud-config.yaml"
+
+#cloud-config
+packages:
+  - nginx
+fooo: bar
+
+--BOUNDARY
+Content-Type: text/x-shellscript; charset="us-ascii"
+
+#!/bin/bash
+echo hello
+
+--BOUNDARY
+Content-Type: text/cloud-config; charset="us-ascii"
+
+runcmd: [
+--BOUNDARY--
+`,
+			expectedWarnings: []string{`unknown cloud-config key "fooo" at part 1 (cloud-config.yaml), line 4`},
+			expectedErrors:   []string{"invalid cloud-config YAML at part 3, line 1"},
+		},
+		{
+			name: "multipartUnknownPartType",
+			content: `Content-Type: multipart/mixed; boundary="BOUNDARY"
+MIME-Version: 1.0
+
+--BOUNDARY
+Content-Type: application/json
+
+{}
+--BOUNDARY--
+`,
+			expectedWarnings: []string{`multipart cloud-init part 1 has unsupported Content-Type "application/json"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := instance.ValidateCloudInit(tt.content, nil)
+
+			errors := []string(nil)
+			warnings := []string(nil)
+
+			for _, d := range diags {
+				if d.Severity == diag.Error {
+					errors = append(errors, d.Summary)
+				} else {
+					warnings = append(warnings, d.Summary)
+				}
+			}
+
+			assert.Len(t, errors, len(tt.expectedErrors))
+			assert.Len(t, warnings, len(tt.expectedWarnings))
+
+			for i, expectedError := range tt.expectedErrors {
+				if i < len(errors) {
+					assert.Contains(t, errors[i], expectedError)
+				}
+			}
+
+			for i, expectedWarning := range tt.expectedWarnings {
+				if i < len(warnings) {
+					assert.Contains(t, warnings[i], expectedWarning)
+				}
+			}
+		})
+	}
+}
+
+func TestIsCloudInitPayload(t *testing.T) {
+	assert.True(t, instance.IsCloudInitPayload("#cloud-config\nruncmd: []\n"))
+	assert.True(t, instance.IsCloudInitPayload("Content-Type: multipart/mixed; boundary=\"b\"\n\n--b--\n"))
+	assert.False(t, instance.IsCloudInitPayload("bar"))
+	assert.False(t, instance.IsCloudInitPayload("#!/bin/sh\n"))
+}
@@ -495,6 +495,52 @@ func instanceIPHasMigrated(d *schema.ResourceData) bool {
 	return false
 }
 
+// serverManagedPublicIP returns the first public IP of the server that is not attached with scaleway_instance_ip_attachment
+func serverManagedPublicIP(server *instance.Server) *instance.ServerIP {
+	if len(server.PublicIPs) == 0 {
+		return server.PublicIP //nolint:staticcheck
+	}
+
+	for _, ip := range server.PublicIPs {
+		if !isIPAttachmentOwned(ip.Tags) {
+			return ip
+		}
+	}
+
+	return nil
+}
+
+// ipAttachmentTag marks the IPs attached with scaleway_instance_ip_attachment, the server resource ignores them
+const ipAttachmentTag = "scaleway_instance_ip_attachment"
+
+// isIPAttachmentOwned returns true if the IP is attached with scaleway_instance_ip_attachment
+func isIPAttachmentOwned(tags []string) bool {
+	for _, tag := range tags {
+		if tag == ipAttachmentTag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withIPAttachmentTag returns the tags of an IP with or without the attachment tag
+func withIPAttachmentTag(tags []string, owned bool) []string {
+	newTags := make([]string, 0, len(tags)+1)
+
+	for _, tag := range tags {
+		if tag != ipAttachmentTag {
+			newTags = append(newTags, tag)
+		}
+	}
+
+	if owned {
+		newTags = append(newTags, ipAttachmentTag)
+	}
+
+	return newTags
+}
+
 func instanceServerAdditionalVolumeTemplate(api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, volumeID string) (*instance.VolumeServerTemplate, error) {
 	vol, err := api.GetUnknownVolume(&instancehelpers.GetUnknownVolumeRequest{
 		VolumeID: locality.ExpandID(volumeID),
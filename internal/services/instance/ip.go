@@ -126,6 +126,22 @@ func ResourceInstanceIPUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 	if d.HasChange("tags") {
 		req.Tags = types.ExpandUpdatedStringsPtr(d.Get("tags"))
+
+		// Keep the tag of IPs attached with scaleway_instance_ip_attachment
+		if d.Get("server_id").(string) != "" {
+			res, err := instanceAPI.GetIP(&instanceSDK.GetIPRequest{
+				IP:   ID,
+				Zone: zone,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			if isIPAttachmentOwned(res.IP.Tags) {
+				tags := withIPAttachmentTag(*req.Tags, true)
+				req.Tags = &tags
+			}
+		}
 	}
 
 	_, err = instanceAPI.UpdateIP(req, scw.WithContext(ctx))
@@ -178,8 +194,8 @@ func ResourceInstanceIPRead(ctx context.Context, d *schema.ResourceData, m inter
 	_ = d.Set("reverse", res.IP.Reverse)
 	_ = d.Set("type", res.IP.Type)
 
-	if len(res.IP.Tags) > 0 {
-		_ = d.Set("tags", types.FlattenSliceString(res.IP.Tags))
+	if tags := withIPAttachmentTag(res.IP.Tags, false); len(tags) > 0 {
+		_ = d.Set("tags", types.FlattenSliceString(tags))
 	}
 
 	if res.IP.Server != nil {
@@ -0,0 +1,235 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceIPAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceInstanceIPAttachmentCreate,
+		ReadContext:   ResourceInstanceIPAttachmentRead,
+		UpdateContext: ResourceInstanceIPAttachmentUpdate,
+		DeleteContext: ResourceInstanceIPAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(DefaultInstanceServerWaitTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"ip_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The ID of the IP to attach",
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+			},
+			"server_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The ID of the server the IP is attached to",
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+			},
+			"address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The attached IP address",
+			},
+			"zone": zonal.Schema(),
+		},
+		CustomizeDiff: cdf.LocalityCheck("ip_id", "server_id"),
+	}
+}
+
+func ResourceInstanceIPAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	instanceAPI, zone, err := newAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ipID := locality.ExpandID(d.Get("ip_id"))
+	serverID := locality.ExpandID(d.Get("server_id"))
+
+	err = attachInstanceIP(ctx, instanceAPI, zone, ipID, serverID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(zonal.NewIDString(zone, ipID))
+
+	return ResourceInstanceIPAttachmentRead(ctx, d, m)
+}
+
+func ResourceInstanceIPAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	instanceAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.GetIP(&instanceSDK.GetIPRequest{
+		IP:   ID,
+		Zone: zone,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		// We check for 403 because instanceSDK API returns 403 for a deleted IP
+		if httperrors.Is404(err) || httperrors.Is403(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	// The attachment does not exist anymore if the IP has been detached outside terraform
+	if res.IP.Server == nil {
+		d.SetId("")
+
+		return nil
+	}
+
+	_ = d.Set("ip_id", zonal.NewIDString(zone, res.IP.ID))
+	_ = d.Set("server_id", zonal.NewIDString(zone, res.IP.Server.ID))
+	_ = d.Set("address", res.IP.Address.String())
+	_ = d.Set("zone", zone.String())
+
+	return nil
+}
+
+func ResourceInstanceIPAttachmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	instanceAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("server_id") {
+		serverID := locality.ExpandID(d.Get("server_id"))
+
+		err = attachInstanceIP(ctx, instanceAPI, zone, ID, serverID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ResourceInstanceIPAttachmentRead(ctx, d, m)
+}
+
+func ResourceInstanceIPAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	instanceAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.GetIP(&instanceSDK.GetIPRequest{
+		IP:   ID,
+		Zone: zone,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) || httperrors.Is403(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	// The IP may have already been moved to another server by another attachment
+	if res.IP.Server == nil || res.IP.Server.ID != locality.ExpandID(d.Get("server_id")) {
+		return nil
+	}
+
+	_, err = waitForServer(ctx, instanceAPI, zone, res.IP.Server.ID, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	tags := withIPAttachmentTag(res.IP.Tags, false)
+
+	_, err = instanceAPI.UpdateIP(&instanceSDK.UpdateIPRequest{
+		Zone:   zone,
+		IP:     ID,
+		Server: &instanceSDK.NullableStringValue{Null: true},
+		Tags:   &tags,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// attachInstanceIP attaches an IP to a server, if the IP is already attached to another server it is moved in a single call.
+// The IP is tagged so that the server resource ignores it.
+func attachInstanceIP(ctx context.Context, instanceAPI *instanceSDK.API, zone scw.Zone, ipID string, serverID string, timeout time.Duration) error {
+	res, err := instanceAPI.GetIP(&instanceSDK.GetIPRequest{
+		IP:   ipID,
+		Zone: zone,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	tags := withIPAttachmentTag(res.IP.Tags, true)
+
+	if res.IP.Server != nil {
+		if res.IP.Server.ID == serverID {
+			if isIPAttachmentOwned(res.IP.Tags) {
+				return nil
+			}
+
+			_, err = instanceAPI.UpdateIP(&instanceSDK.UpdateIPRequest{
+				Zone: zone,
+				IP:   ipID,
+				Tags: &tags,
+			}, scw.WithContext(ctx))
+
+			return err
+		}
+
+		// The previous server must not be in a transient state for the IP to be released
+		_, err = waitForServer(ctx, instanceAPI, zone, res.IP.Server.ID, timeout)
+		if err != nil && !httperrors.Is404(err) {
+			return err
+		}
+	}
+
+	_, err = waitForServer(ctx, instanceAPI, zone, serverID, timeout)
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("attaching IP %q to server %q", ipID, serverID))
+
+	_, err = instanceAPI.UpdateIP(&instanceSDK.UpdateIPRequest{
+		Zone:   zone,
+		IP:     ipID,
+		Server: &instanceSDK.NullableStringValue{Value: serverID},
+		Tags:   &tags,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to attach IP %s to server %s: %w", ipID, serverID, err)
+	}
+
+	_, err = waitForServer(ctx, instanceAPI, zone, serverID, timeout)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package instance_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	instancechecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/testfuncs"
+)
+
+func TestAccIPAttachment_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			instancechecks.IsServerDestroyed(tt),
+			instancechecks.IsIPDestroyed(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_ip" "managed" {
+						type = "routed_ipv4"
+					}
+
+					resource "scaleway_instance_ip" "attached" {
+						type = "routed_ipv4"
+						tags = ["foo"]
+					}
+
+					resource "scaleway_instance_server" "main" {
+						name   = "tf-tests-instance-ip-attachment"
+						ip_ids = [scaleway_instance_ip.managed.id]
+						image  = "ubuntu_jammy"
+						type   = "PRO2-XXS"
+						state  = "stopped"
+					}
+
+					resource "scaleway_instance_server" "other" {
+						name  = "tf-tests-instance-ip-attachment-other"
+						image = "ubuntu_jammy"
+						type  = "PRO2-XXS"
+						state = "stopped"
+					}
+
+					resource "scaleway_instance_ip_attachment" "main" {
+						ip_id     = scaleway_instance_ip.attached.id
+						server_id = scaleway_instance_server.main.id
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_instance_ip_attachment.main", "ip_id", "scaleway_instance_ip.attached", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_instance_ip_attachment.main", "server_id", "scaleway_instance_server.main", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_instance_ip_attachment.main", "address", "scaleway_instance_ip.attached", "address"),
+				),
+			},
+			{
+				// The server ignores the IP of the attachment and the IP keeps its own tags
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_instance_server.main", "ip_ids.#", "1"),
+					resource.TestCheckResourceAttr("scaleway_instance_server.main", "public_ips.#", "2"),
+					resource.TestCheckResourceAttr("scaleway_instance_ip.attached", "tags.#", "1"),
+					resource.TestCheckResourceAttr("scaleway_instance_ip.attached", "tags.0", "foo"),
+				),
+			},
+			{
+				Config: `
+					resource "scaleway_instance_ip" "managed" {
+						type = "routed_ipv4"
+					}
+
+					resource "scaleway_instance_ip" "attached" {
+						type = "routed_ipv4"
+						tags = ["foo"]
+					}
+
+					resource "scaleway_instance_server" "main" {
+						name   = "tf-tests-instance-ip-attachment"
+						ip_ids = [scaleway_instance_ip.managed.id]
+						image  = "ubuntu_jammy"
+						type   = "PRO2-XXS"
+						state  = "stopped"
+					}
+
+					resource "scaleway_instance_server" "other" {
+						name  = "tf-tests-instance-ip-attachment-other"
+						image = "ubuntu_jammy"
+						type  = "PRO2-XXS"
+						state = "stopped"
+					}
+
+					resource "scaleway_instance_ip_attachment" "main" {
+						ip_id     = scaleway_instance_ip.attached.id
+						server_id = scaleway_instance_server.other.id
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_instance_ip_attachment.main", "server_id", "scaleway_instance_server.other", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_instance_ip.attached", "server_id", "scaleway_instance_server.other", "id"),
+				),
+			},
+			{
+				ResourceName:      "scaleway_instance_ip_attachment.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -280,6 +280,7 @@ func ResourceServer() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
+				ValidateDiagFunc: validateCloudInitUserData(),
 				DiffSuppressFunc: func(k, _, _ string, _ *schema.ResourceData) bool {
 					return k == "user_data.ssh-host-fingerprints"
 				},
@@ -354,6 +355,7 @@ func ResourceServer() *schema.Resource {
 			customDiffInstanceServerType,
 			customDiffInstanceServerImage,
 			customDiffInstanceRootVolumeSize,
+			customDiffInstanceServerCloudInit,
 		),
 	}
 }
@@ -632,9 +634,10 @@ func ResourceInstanceServerRead(ctx context.Context, d *schema.ResourceData, m i
 			_ = d.Set("private_ip", types.FlattenStringPtr(server.PrivateIP))
 		}
 
-		if _, hasIPID := d.GetOk("ip_id"); server.PublicIP != nil && hasIPID { //nolint:staticcheck
-			if !server.PublicIP.Dynamic { //nolint:staticcheck
-				_ = d.Set("ip_id", zonal.NewID(zone, server.PublicIP.ID).String()) //nolint:staticcheck
+		publicIP := serverManagedPublicIP(server)
+		if _, hasIPID := d.GetOk("ip_id"); publicIP != nil && hasIPID {
+			if !publicIP.Dynamic {
+				_ = d.Set("ip_id", zonal.NewID(zone, publicIP.ID).String())
 			} else {
 				_ = d.Set("ip_id", "")
 			}
@@ -857,10 +860,11 @@ func ResourceInstanceServerUpdate(ctx context.Context, d *schema.ResourceData, m
 
 		ipID := zonal.ExpandID(d.Get("ip_id")).ID
 		// If an IP is already attached, and it's not a dynamic IP we detach it.
-		if server.PublicIP != nil && !server.PublicIP.Dynamic { //nolint:staticcheck
+		// IPs attached with scaleway_instance_ip_attachment are left untouched.
+		if publicIP := serverManagedPublicIP(server); publicIP != nil && !publicIP.Dynamic {
 			_, err = api.UpdateIP(&instanceSDK.UpdateIPRequest{
 				Zone:   zone,
-				IP:     server.PublicIP.ID, //nolint:staticcheck
+				IP:     publicIP.ID,
 				Server: &instanceSDK.NullableStringValue{Null: true},
 			})
 			if err != nil {
@@ -1330,11 +1334,12 @@ func ResourceInstanceServerUpdateIPs(ctx context.Context, d *schema.ResourceData
 	}
 
 	// Detach all IPs that are not requested and set to true the one that are already attached
+	// IPs attached with scaleway_instance_ip_attachment are left untouched
 	for _, ip := range server.PublicIPs {
 		_, isRequested := requestedIPs[ip.ID]
 		if isRequested {
 			requestedIPs[ip.ID] = true
-		} else {
+		} else if !isIPAttachmentOwned(ip.Tags) {
 			_, err := instanceAPI.UpdateIP(&instanceSDK.UpdateIPRequest{
 				Zone: zone,
 				IP:   ip.ID,
@@ -101,11 +101,14 @@ func flattenServerPublicIPs(zone scw.Zone, ips []*instance.ServerIP) []interface
 	return flattenedIPs
 }
 
+// flattenServerIPIDs returns the IDs of attached IPs, IPs attached with scaleway_instance_ip_attachment are ignored
 func flattenServerIPIDs(ips []*instance.ServerIP) []interface{} {
-	ipIDs := make([]interface{}, len(ips))
+	ipIDs := make([]interface{}, 0, len(ips))
 
-	for i, ip := range ips {
-		ipIDs[i] = ip.ID
+	for _, ip := range ips {
+		if !isIPAttachmentOwned(ip.Tags) {
+			ipIDs = append(ipIDs, ip.ID)
+		}
 	}
 
 	return ipIDs
@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -46,13 +47,17 @@ func ResourceUserData() *schema.Resource {
 				Description: "The key of the user data to set.",
 			},
 			"value": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The value of the user data to set.",
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The value of the user data to set.",
+				ValidateDiagFunc: validateCloudInitValue(),
 			},
 			"zone": zonal.Schema(),
 		},
-		CustomizeDiff: cdf.LocalityCheck("server_id"),
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("server_id"),
+			customDiffInstanceUserDataCloudInit,
+		),
 	}
 }
 
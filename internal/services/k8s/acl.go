@@ -0,0 +1,254 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceACL() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceK8SACLCreate,
+		ReadContext:   ResourceK8SACLRead,
+		UpdateContext: ResourceK8SACLUpdate,
+		DeleteContext: ResourceK8SACLDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultK8SClusterTimeout),
+			Read:    schema.DefaultTimeout(defaultK8SClusterTimeout),
+			Update:  schema.DefaultTimeout(defaultK8SClusterTimeout),
+			Delete:  schema.DefaultTimeout(defaultK8SClusterTimeout),
+			Default: schema.DefaultTimeout(defaultK8SClusterTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "Cluster on which the ACL should be applied",
+			},
+			"no_ip_allowed": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"acl_rules"},
+				Description:   "If set to true, no IP will be allowed and the cluster will be fully isolated",
+			},
+			"acl_rules": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{"acl_rules", "no_ip_allowed"},
+				Description:  "The list of network rules that manage inbound traffic",
+				Set:          k8sACLRuleHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the ACL rule",
+						},
+						"ip": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.Any(validation.IsCIDR, validation.IsIPAddress),
+							DiffSuppressFunc: func(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+								return NormalizeACLRuleIP(oldValue) == NormalizeACLRuleIP(newValue)
+							},
+							Description: "The IP subnet to be allowed, a single address is a /32 or /128 subnet",
+						},
+						"scaleway_ranges": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Allow access to cluster from all Scaleway ranges as defined in https://www.scaleway.com/en/docs/console/account/reference-content/scaleway-network-information/#ip-ranges-used-by-scaleway",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The description of the ACL rule",
+						},
+					},
+				},
+			},
+			// Common
+			"region": regional.Schema(),
+		},
+		CustomizeDiff: cdf.LocalityCheck("cluster_id"),
+	}
+}
+
+func ResourceK8SACLCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	k8sAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clusterID := locality.ExpandID(d.Get("cluster_id"))
+
+	err = setK8SClusterACLRules(ctx, k8sAPI, region, clusterID, d, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(regional.NewIDString(region, clusterID))
+
+	return ResourceK8SACLRead(ctx, d, m)
+}
+
+func ResourceK8SACLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	k8sAPI, region, clusterID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	res, err := k8sAPI.ListClusterACLRules(&k8s.ListClusterACLRulesRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	aclRules, err := flattenK8SACLRules(res.Rules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("cluster_id", regional.NewIDString(region, clusterID))
+	_ = d.Set("region", region)
+	_ = d.Set("no_ip_allowed", len(res.Rules) == 0)
+	_ = d.Set("acl_rules", aclRules)
+
+	return nil
+}
+
+func ResourceK8SACLUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	k8sAPI, region, clusterID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("acl_rules", "no_ip_allowed") {
+		err = setK8SClusterACLRules(ctx, k8sAPI, region, clusterID, d, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ResourceK8SACLRead(ctx, d, m)
+}
+
+// ResourceK8SACLDelete restores the default ACL allowing every IP to reach the cluster
+func ResourceK8SACLDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	k8sAPI, region, clusterID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	defaultIP, err := types.ExpandIPNet(defaultK8SACLRuleIP)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = k8sAPI.SetClusterACLRules(&k8s.SetClusterACLRulesRequest{
+		Region:    region,
+		ClusterID: clusterID,
+		ACLs: []*k8s.ACLRuleRequest{
+			{
+				IP:          &defaultIP,
+				Description: "Automatically generated after scaleway_k8s_acl resource deletion",
+			},
+		},
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// setK8SClusterACLRules replaces the ACL rules of the cluster once it is out of any transient state
+func setK8SClusterACLRules(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, d *schema.ResourceData, timeout time.Duration) error {
+	acls := []*k8s.ACLRuleRequest{}
+
+	if !d.Get("no_ip_allowed").(bool) {
+		expandedACLs, err := expandK8SACLRules(d.Get("acl_rules").(*schema.Set).List())
+		if err != nil {
+			return err
+		}
+
+		if len(expandedACLs) == 0 {
+			return errors.New("acl_rules must contain at least one rule when no_ip_allowed is false")
+		}
+
+		acls = expandedACLs
+	}
+
+	_, err := waitCluster(ctx, k8sAPI, region, clusterID, timeout)
+	if err != nil {
+		return err
+	}
+
+	_, err = k8sAPI.SetClusterACLRules(&k8s.SetClusterACLRulesRequest{
+		Region:    region,
+		ClusterID: clusterID,
+		ACLs:      acls,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to set cluster ACL rules: %w", err)
+	}
+
+	_, err = waitCluster(ctx, k8sAPI, region, clusterID, timeout)
+
+	return err
+}
@@ -0,0 +1,134 @@
+package k8s_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	k8sSDK "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
+	vpcchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc/testfuncs"
+)
+
+func TestAccACL_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestK8SVersion := testAccK8SClusterGetLatestK8SVersion(tt)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckK8SClusterDestroy(tt),
+			vpcchecks.CheckPrivateNetworkDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckK8SACLConfig(latestK8SVersion, `
+	acl_rules {
+		ip          = "1.2.3.4/32"
+		description = "Allow 1.2.3.4"
+	}
+
+	acl_rules {
+		scaleway_ranges = true
+		description     = "Allow all Scaleway ranges"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckK8SACLRulesCount(tt, "scaleway_k8s_acl.main", 2),
+					resource.TestCheckResourceAttrPair("scaleway_k8s_acl.main", "cluster_id", "scaleway_k8s_cluster.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_k8s_acl.main", "acl_rules.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_k8s_acl.main", "acl_rules.*", map[string]string{
+						"ip":          "1.2.3.4/32",
+						"description": "Allow 1.2.3.4",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_k8s_acl.main", "acl_rules.*", map[string]string{
+						"scaleway_ranges": "true",
+						"description":     "Allow all Scaleway ranges",
+					}),
+				),
+			},
+			{
+				Config: testAccCheckK8SACLConfig(latestK8SVersion, `
+	acl_rules {
+		ip          = "5.6.7.8/32"
+		description = "Allow 5.6.7.8"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckK8SACLRulesCount(tt, "scaleway_k8s_acl.main", 1),
+					resource.TestCheckResourceAttr("scaleway_k8s_acl.main", "acl_rules.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_k8s_acl.main", "acl_rules.*", map[string]string{
+						"ip":          "5.6.7.8/32",
+						"description": "Allow 5.6.7.8",
+					}),
+				),
+			},
+			{
+				Config: testAccCheckK8SACLConfig(latestK8SVersion, `
+	no_ip_allowed = true`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckK8SACLRulesCount(tt, "scaleway_k8s_acl.main", 0),
+					resource.TestCheckResourceAttr("scaleway_k8s_acl.main", "no_ip_allowed", "true"),
+					resource.TestCheckResourceAttr("scaleway_k8s_acl.main", "acl_rules.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "scaleway_k8s_acl.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckK8SACLConfig(version string, rules string) string {
+	return fmt.Sprintf(`
+resource "scaleway_vpc_private_network" "main" {
+	name = "test-k8s-acl"
+}
+
+resource "scaleway_k8s_cluster" "main" {
+	name                        = "test-k8s-acl"
+	version                     = "%s"
+	cni                         = "cilium"
+	delete_additional_resources = true
+	private_network_id          = scaleway_vpc_private_network.main.id
+}
+
+resource "scaleway_k8s_acl" "main" {
+	cluster_id = scaleway_k8s_cluster.main.id
+%s
+}`, version, rules)
+}
+
+func testAccCheckK8SACLRulesCount(tt *acctest.TestTools, n string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		k8sAPI, region, clusterID, err := k8s.NewAPIWithRegionAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		rules, err := k8sAPI.ListClusterACLRules(&k8sSDK.ListClusterACLRulesRequest{
+			Region:    region,
+			ClusterID: clusterID,
+		}, scw.WithAllPages())
+		if err != nil {
+			return err
+		}
+
+		if len(rules.Rules) != expected {
+			return fmt.Errorf("expected %d ACL rules on cluster %s, got %d", expected, clusterID, len(rules.Rules))
+		}
+
+		return nil
+	}
+}
@@ -63,6 +63,13 @@ func ResourceCluster() *schema.Resource {
 				Required:    true,
 				Description: "The version of the cluster",
 			},
+			"upgrade_pools": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          upgradePoolsAuto,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{upgradePoolsAuto, upgradePoolsSequential, upgradePoolsNone}, false)),
+				Description:      "How the pools are upgraded when the cluster version changes: auto lets the API upgrade them along with the control plane, sequential upgrades them one after the other once the control plane is upgraded, none only upgrades the control plane",
+			},
 			"cni": {
 				Type:             schema.TypeString,
 				Required:         true,
@@ -277,6 +284,7 @@ func ResourceCluster() *schema.Resource {
 
 				return nil
 			},
+			customizeDiffClusterVersionUpgrade,
 			func(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
 				if diff.HasChange("type") && diff.Id() != "" {
 					k8sAPI, region, clusterID, err := NewAPIWithRegionAndID(i, diff.Id())
@@ -309,6 +317,50 @@ func ResourceCluster() *schema.Resource {
 	}
 }
 
+// customizeDiffClusterVersionUpgrade validates the minor version skew of a cluster upgrade,
+// and when pools are not upgraded along with the control plane, that they remain supported by the new version
+func customizeDiffClusterVersionUpgrade(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("version") || !diff.NewValueKnown("version") {
+		return nil
+	}
+
+	oldVersion, newVersion := diff.GetChange("version")
+	if oldVersion.(string) == "" {
+		return nil
+	}
+
+	err := ValidateClusterVersionUpgrade(oldVersion.(string), newVersion.(string))
+	if err != nil {
+		return err
+	}
+
+	if diff.Get("upgrade_pools").(string) != upgradePoolsNone {
+		return nil
+	}
+
+	k8sAPI, region, clusterID, err := NewAPIWithRegionAndID(m, diff.Id())
+	if err != nil {
+		return err
+	}
+
+	pools, err := k8sAPI.ListPools(&k8s.ListPoolsRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools.Pools {
+		err = ValidatePoolVersionSkew(pool.Version, newVersion.(string))
+		if err != nil {
+			return fmt.Errorf("pool %s would not be supported after the upgrade, upgrade it first or use upgrade_pools = %q: %w", pool.Name, upgradePoolsSequential, err)
+		}
+	}
+
+	return nil
+}
+
 //gocyclo:ignore
 func ResourceK8SClusterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	k8sAPI, region, err := newAPIWithRegion(d, m)
@@ -847,11 +899,13 @@ func ResourceK8SClusterUpdate(ctx context.Context, d *schema.ResourceData, m int
 	// Upgrade if needed
 	////
 	if canUpgrade {
+		upgradePools := d.Get("upgrade_pools").(string)
+
 		upgradeRequest := &k8s.UpgradeClusterRequest{
 			Region:       region,
 			ClusterID:    clusterID,
 			Version:      version,
-			UpgradePools: true,
+			UpgradePools: upgradePools == upgradePoolsAuto,
 		}
 
 		_, err = k8sAPI.UpgradeCluster(upgradeRequest)
@@ -864,7 +918,20 @@ func ResourceK8SClusterUpdate(ctx context.Context, d *schema.ResourceData, m int
 			return append(diag.FromErr(err), diags...)
 		}
 
-		if !strings.Contains(d.Get("type").(string), "multicloud") {
+		if upgradePools == upgradePoolsSequential {
+			progress, err := upgradeClusterPoolsSequentially(ctx, k8sAPI, region, clusterID, version, d.Timeout(schema.TimeoutUpdate))
+			if len(progress) > 0 {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Pools upgraded sequentially",
+					Detail:   strings.Join(progress, "\n"),
+				})
+			}
+
+			if err != nil {
+				return append(diag.FromErr(err), diags...)
+			}
+		} else if upgradePools == upgradePoolsAuto && !strings.Contains(d.Get("type").(string), "multicloud") {
 			// In case of multi-cloud, we do not have the guarantee that a pool will be created in Scaleway.
 			// But if we are not, we can wait for the pool to be upgraded.
 			_, err = waitClusterPool(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutUpdate))
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -19,8 +20,26 @@ const (
 	defaultK8SClusterTimeout = 15 * time.Minute
 	defaultK8SPoolTimeout    = 30 * time.Minute
 	defaultK8SRetryInterval  = 5 * time.Second
+
+	defaultK8SACLRuleIP = "0.0.0.0/0"
+
+	// maxK8SPoolMinorVersionSkew is the number of minor versions a kubelet can lag behind the control plane
+	maxK8SPoolMinorVersionSkew = 3
+
+	upgradePoolsAuto       = "auto"
+	upgradePoolsSequential = "sequential"
+	upgradePoolsNone       = "none"
+
+	poolReplacementStrategyRecreate  = "recreate"
+	poolReplacementStrategyBlueGreen = "blue_green"
+
+	poolBlueSuffix  = "-blue"
+	poolGreenSuffix = "-green"
 )
 
+// poolReplacementFields are the pool fields that cannot be updated in place
+var poolReplacementFields = []string{"node_type", "root_volume_type", "container_runtime"}
+
 func newAPIWithRegion(d *schema.ResourceData, m interface{}) (*k8s.API, scw.Region, error) {
 	k8sAPI := k8s.NewAPI(meta.ExtractScwClient(m))
 
@@ -52,6 +71,147 @@ func GetMinorVersionFromFull(version string) (string, error) {
 	return versionSplit[0] + "." + versionSplit[1], nil
 }
 
+// k8sMinorVersionNumbers returns the major and minor numbers of a x.y or x.y.z version
+func k8sMinorVersionNumbers(version string) (int, int, error) {
+	minorVersion := version
+	if strings.Count(version, ".") == 2 {
+		minorVersion, _ = GetMinorVersionFromFull(version)
+	}
+
+	var major, minor int
+
+	_, err := fmt.Sscanf(minorVersion, "%d.%d", &major, &minor)
+	if err != nil || fmt.Sprintf("%d.%d", major, minor) != minorVersion {
+		return 0, 0, fmt.Errorf("version should be like x.y or x.y.z not %s", version)
+	}
+
+	return major, minor, nil
+}
+
+// k8sVersionMatches returns true if version is the target version, target may be a minor version (x.y)
+func k8sVersionMatches(version string, target string) bool {
+	if version == target {
+		return true
+	}
+
+	minorVersion, err := GetMinorVersionFromFull(version)
+
+	return err == nil && minorVersion == target
+}
+
+// ValidateClusterVersionUpgrade checks that a control plane can go from current to target version,
+// Kubernetes only supports upgrading one minor version at a time and never downgrading
+func ValidateClusterVersionUpgrade(current string, target string) error {
+	currentMajor, currentMinor, err := k8sMinorVersionNumbers(current)
+	if err != nil {
+		return err
+	}
+
+	targetMajor, targetMinor, err := k8sMinorVersionNumbers(target)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case targetMajor != currentMajor:
+		return fmt.Errorf("cannot upgrade cluster from %s to %s: major version upgrades are not supported", current, target)
+	case targetMinor < currentMinor:
+		return fmt.Errorf("cannot downgrade cluster from %s to %s", current, target)
+	case targetMinor > currentMinor+1:
+		return fmt.Errorf("cannot upgrade cluster from %s to %s: minor versions must be upgraded one at a time, upgrade to %d.%d first", current, target, currentMajor, currentMinor+1)
+	}
+
+	return nil
+}
+
+// ValidatePoolVersionSkew checks that a pool running poolVersion is supported by a control plane running clusterVersion
+func ValidatePoolVersionSkew(poolVersion string, clusterVersion string) error {
+	poolMajor, poolMinor, err := k8sMinorVersionNumbers(poolVersion)
+	if err != nil {
+		return err
+	}
+
+	clusterMajor, clusterMinor, err := k8sMinorVersionNumbers(clusterVersion)
+	if err != nil {
+		return err
+	}
+
+	if poolMajor != clusterMajor || poolMinor > clusterMinor || clusterMinor-poolMinor > maxK8SPoolMinorVersionSkew {
+		return fmt.Errorf("pool version %s is not supported by a control plane in version %s: pools can be at most %d minor versions older than the control plane", poolVersion, clusterVersion, maxK8SPoolMinorVersionSkew)
+	}
+
+	return nil
+}
+
+// upgradeClusterPoolsSequentially upgrades the pools of a cluster one after the other,
+// each pool is upgraded by the API following its own upgrade policy (max_surge and max_unavailable).
+// It returns the progress of each pool, including on failure.
+func upgradeClusterPoolsSequentially(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, version string, timeout time.Duration) ([]string, error) {
+	pools, err := k8sAPI.ListPools(&k8s.ListPoolsRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]string, 0, len(pools.Pools))
+
+	for i, pool := range pools.Pools {
+		step := fmt.Sprintf("pool %s (%d/%d)", pool.Name, i+1, len(pools.Pools))
+
+		if k8sVersionMatches(pool.Version, version) {
+			progress = append(progress, fmt.Sprintf("%s is already in version %s", step, pool.Version))
+
+			continue
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("upgrading %s from %s to %s", step, pool.Version, version))
+
+		_, err = waitPoolReady(ctx, k8sAPI, region, pool.ID, timeout)
+		if err != nil {
+			return progress, fmt.Errorf("%s is not ready to be upgraded: %w", step, err)
+		}
+
+		upgradedPool, err := k8sAPI.UpgradePool(&k8s.UpgradePoolRequest{
+			Region:  region,
+			PoolID:  pool.ID,
+			Version: version,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return progress, fmt.Errorf("failed to upgrade %s: %w", step, err)
+		}
+
+		upgradedPool, err = waitPoolUpgraded(ctx, k8sAPI, upgradedPool, version, timeout)
+		if err != nil {
+			return progress, fmt.Errorf("failed to wait for %s upgrade: %w", step, err)
+		}
+
+		progress = append(progress, fmt.Sprintf("%s upgraded from %s to %s", step, pool.Version, upgradedPool.Version))
+		tflog.Info(ctx, progress[len(progress)-1])
+	}
+
+	return progress, nil
+}
+
+// blueGreenPoolName returns the name of the pool replacing currentName, alternating between the blue and green suffixes
+func blueGreenPoolName(name string, currentName string) string {
+	if currentName == name+poolBlueSuffix {
+		return name + poolGreenSuffix
+	}
+
+	return name + poolBlueSuffix
+}
+
+// poolBaseName returns the configured name of a pool that may have been suffixed by a blue/green replacement
+func poolBaseName(name string, poolName string) string {
+	if name != "" && (poolName == name+poolBlueSuffix || poolName == name+poolGreenSuffix) {
+		return name
+	}
+
+	return poolName
+}
+
 // k8sGetLatestVersionFromMinor returns the latest full version (x.y.z) for a given minor version (x.y)
 func k8sGetLatestVersionFromMinor(ctx context.Context, k8sAPI *k8s.API, region scw.Region, version string) (string, error) {
 	versionSplit := strings.Split(version, ".")
@@ -117,3 +277,25 @@ func getNodes(ctx context.Context, k8sAPI *k8s.API, pool *k8s.Pool) ([]map[strin
 
 	return convertNodes(nodes), nil
 }
+
+// findReplacedNode returns the node that took the name of a replaced node in its pool, nil if there is none yet
+func findReplacedNode(ctx context.Context, k8sAPI *k8s.API, node *k8s.Node) (*k8s.Node, error) {
+	nodes, err := k8sAPI.ListNodes(&k8s.ListNodesRequest{
+		Region:    node.Region,
+		ClusterID: node.ClusterID,
+		PoolID:    &node.PoolID,
+		Name:      &node.Name,
+	}, scw.WithContext(ctx), scw.WithAllPages())
+	if err != nil {
+		return nil, err
+	}
+
+	// The name filter may also match nodes whose name only starts with the name of the node
+	for _, n := range nodes.Nodes {
+		if n.Name == node.Name && n.ID != node.ID {
+			return n, nil
+		}
+	}
+
+	return nil, nil
+}
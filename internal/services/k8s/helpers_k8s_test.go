@@ -0,0 +1,54 @@
+package k8s_test
+
+import (
+	"testing"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateClusterVersionUpgrade(t *testing.T) {
+	tests := []struct {
+		current string
+		target  string
+		valid   bool
+	}{
+		{"1.30.2", "1.30.5", true},
+		{"1.30.2", "1.31.1", true},
+		{"1.30", "1.31", true},
+		{"1.30", "1.31.1", true},
+		{"1.30.2", "1.32.0", false},
+		{"1.31.1", "1.30.2", false},
+		{"1.31.1", "2.0.0", false},
+		{"1.31.1", "latest", false},
+		{"1.31.1", "1.31.1.1", false},
+		{"1.x", "1.31", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current+"->"+tt.target, func(t *testing.T) {
+			err := k8s.ValidateClusterVersionUpgrade(tt.current, tt.target)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatePoolVersionSkew(t *testing.T) {
+	assert.NoError(t, k8s.ValidatePoolVersionSkew("1.31.1", "1.31.1"))
+	assert.NoError(t, k8s.ValidatePoolVersionSkew("1.28.9", "1.31.1"))
+	assert.Error(t, k8s.ValidatePoolVersionSkew("1.27.9", "1.31.1"))
+	assert.Error(t, k8s.ValidatePoolVersionSkew("1.32.0", "1.31.1"))
+}
+
+func TestNormalizeACLRuleIP(t *testing.T) {
+	assert.Equal(t, "10.0.0.1/32", k8s.NormalizeACLRuleIP("10.0.0.1"))
+	assert.Equal(t, "10.0.0.1/32", k8s.NormalizeACLRuleIP("10.0.0.1/32"))
+	assert.Equal(t, "10.0.0.0/24", k8s.NormalizeACLRuleIP("10.0.0.0/24"))
+	assert.Equal(t, "2001:db8::1/128", k8s.NormalizeACLRuleIP("2001:0db8::1"))
+	assert.Empty(t, k8s.NormalizeACLRuleIP(""))
+	assert.Equal(t, "not an ip", k8s.NormalizeACLRuleIP("not an ip"))
+}
@@ -0,0 +1,263 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+)
+
+const (
+	kubernetesMirrorPodAnnotation = "kubernetes.io/config.mirror"
+	kubernetesDaemonSetKind       = "DaemonSet"
+)
+
+// KubernetesClient is a minimal client of the Kubernetes API used to cordon and drain nodes
+type KubernetesClient struct {
+	httpClient *http.Client
+	host       string
+	token      string
+}
+
+type kubernetesPodList struct {
+	Items []kubernetesPod `json:"items"`
+}
+
+type kubernetesPod struct {
+	Metadata struct {
+		Name            string            `json:"name"`
+		Namespace       string            `json:"namespace"`
+		Annotations     map[string]string `json:"annotations"`
+		OwnerReferences []struct {
+			Kind string `json:"kind"`
+		} `json:"ownerReferences"`
+	} `json:"metadata"`
+	Status struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+// isEvictable returns false for the pods a drain must leave on the node: mirror pods, daemonset pods and terminated pods
+func (p *kubernetesPod) isEvictable() bool {
+	if _, isMirror := p.Metadata.Annotations[kubernetesMirrorPodAnnotation]; isMirror {
+		return false
+	}
+
+	for _, owner := range p.Metadata.OwnerReferences {
+		if owner.Kind == kubernetesDaemonSetKind {
+			return false
+		}
+	}
+
+	return p.Status.Phase != "Succeeded" && p.Status.Phase != "Failed"
+}
+
+// NewKubernetesClient creates a client from the host, base64 encoded certificate authority data and token of a kubeconfig
+func NewKubernetesClient(host string, caData string, token string) (*KubernetesClient, error) {
+	caPEM, err := base64.StdEncoding.DecodeString(caData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cluster certificate authority: %w", err)
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("failed to parse cluster certificate authority")
+	}
+
+	return &KubernetesClient{
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:    caPool,
+					MinVersion: tls.VersionTLS12,
+				},
+			},
+		},
+		host:  strings.TrimSuffix(host, "/"),
+		token: token,
+	}, nil
+}
+
+func (c *KubernetesClient) do(ctx context.Context, method string, path string, contentType string, body interface{}, out interface{}) (int, error) {
+	var reqBody io.Reader
+
+	if body != nil {
+		rawBody, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+
+		reqBody = bytes.NewReader(rawBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.host+path, reqBody)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	rawResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp.StatusCode, fmt.Errorf("kubernetes API %s %s returned %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(rawResp)))
+	}
+
+	if out != nil {
+		err = json.Unmarshal(rawResp, out)
+		if err != nil {
+			return resp.StatusCode, err
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+// CordonNode marks the node as unschedulable
+func (c *KubernetesClient) CordonNode(ctx context.Context, nodeName string) error {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"unschedulable": true,
+		},
+	}
+
+	_, err := c.do(ctx, http.MethodPatch, "/api/v1/nodes/"+url.PathEscape(nodeName), "application/merge-patch+json", patch, nil)
+
+	return err
+}
+
+func (c *KubernetesClient) listEvictablePods(ctx context.Context, nodeName string) ([]kubernetesPod, error) {
+	query := url.Values{}
+	query.Set("fieldSelector", "spec.nodeName="+nodeName)
+
+	pods := &kubernetesPodList{}
+
+	_, err := c.do(ctx, http.MethodGet, "/api/v1/pods?"+query.Encode(), "", nil, pods)
+	if err != nil {
+		return nil, err
+	}
+
+	evictablePods := []kubernetesPod(nil)
+
+	for _, pod := range pods.Items {
+		if pod.isEvictable() {
+			evictablePods = append(evictablePods, pod)
+		}
+	}
+
+	return evictablePods, nil
+}
+
+// evictPod asks the API to evict a pod, it returns false if the eviction is currently blocked by a PodDisruptionBudget
+func (c *KubernetesClient) evictPod(ctx context.Context, pod kubernetesPod) (bool, error) {
+	eviction := map[string]interface{}{
+		"apiVersion": "policy/v1",
+		"kind":       "Eviction",
+		"metadata": map[string]interface{}{
+			"name":      pod.Metadata.Name,
+			"namespace": pod.Metadata.Namespace,
+		},
+	}
+
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/eviction", url.PathEscape(pod.Metadata.Namespace), url.PathEscape(pod.Metadata.Name))
+
+	statusCode, err := c.do(ctx, http.MethodPost, path, "application/json", eviction, nil)
+
+	switch {
+	case statusCode == http.StatusNotFound:
+		return true, nil
+	case statusCode == http.StatusTooManyRequests:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DrainNode evicts the pods of a node until none is left, pods protected by a PodDisruptionBudget are retried until the context is done
+func (c *KubernetesClient) DrainNode(ctx context.Context, nodeName string) error {
+	retryInterval := defaultK8SRetryInterval
+	if transport.DefaultWaitRetryInterval != nil {
+		retryInterval = *transport.DefaultWaitRetryInterval
+	}
+
+	for {
+		pods, err := c.listEvictablePods(ctx, nodeName)
+		if err != nil {
+			return fmt.Errorf("failed to list pods of node %s: %w", nodeName, err)
+		}
+
+		if len(pods) == 0 {
+			return nil
+		}
+
+		for _, pod := range pods {
+			evicted, err := c.evictPod(ctx, pod)
+			if err != nil {
+				return fmt.Errorf("failed to evict pod %s/%s: %w", pod.Metadata.Namespace, pod.Metadata.Name, err)
+			}
+
+			if !evicted {
+				tflog.Debug(ctx, fmt.Sprintf("eviction of pod %s/%s is blocked by a disruption budget, retrying", pod.Metadata.Namespace, pod.Metadata.Name))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout while draining node %s: %d pods left", nodeName, len(pods))
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// CordonAndDrainNodes cordons every node first so that evicted pods are not rescheduled on the other nodes being drained.
+// The timeout applies to the whole operation, not to each node.
+func (c *KubernetesClient) CordonAndDrainNodes(ctx context.Context, nodeNames []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, nodeName := range nodeNames {
+		err := c.CordonNode(ctx, nodeName)
+		if err != nil {
+			return fmt.Errorf("failed to cordon node %s: %w", nodeName, err)
+		}
+	}
+
+	for _, nodeName := range nodeNames {
+		tflog.Info(ctx, "draining node "+nodeName)
+
+		err := c.DrainNode(ctx, nodeName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package k8s_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKubeAPIServer serves the subset of the Kubernetes API used to cordon and drain nodes
+type fakeKubeAPIServer struct {
+	mu sync.Mutex
+	// pods maps a pod name to its node, daemonset pods are prefixed with "ds-"
+	pods map[string]string
+	// blockedEvictions is the number of evictions refused with a 429 before succeeding
+	blockedEvictions int
+	cordoned         []string
+	evicted          []string
+}
+
+func (f *fakeKubeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/api/v1/nodes/"):
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Content-Type") != "application/merge-patch+json" || !strings.Contains(string(body), `"unschedulable":true`) {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		f.cordoned = append(f.cordoned, strings.TrimPrefix(r.URL.Path, "/api/v1/nodes/"))
+		_, _ = w.Write([]byte("{}"))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/pods":
+		nodeName := strings.TrimPrefix(r.URL.Query().Get("fieldSelector"), "spec.nodeName=")
+		items := []map[string]interface{}(nil)
+
+		for podName, podNode := range f.pods {
+			if podNode != nodeName {
+				continue
+			}
+
+			metadata := map[string]interface{}{"name": podName, "namespace": "default"}
+			if strings.HasPrefix(podName, "ds-") {
+				metadata["ownerReferences"] = []map[string]interface{}{{"kind": "DaemonSet"}}
+			}
+
+			items = append(items, map[string]interface{}{"metadata": metadata, "status": map[string]interface{}{"phase": "Running"}})
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/eviction"):
+		if f.blockedEvictions > 0 {
+			f.blockedEvictions--
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		podName := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/namespaces/default/pods/"), "/eviction")
+		delete(f.pods, podName)
+		f.evicted = append(f.evicted, podName)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// setTestRetryInterval overrides the wait retry interval for the duration of the test
+func setTestRetryInterval(t *testing.T, retryInterval time.Duration) {
+	t.Helper()
+
+	previousRetryInterval := transport.DefaultWaitRetryInterval
+	transport.DefaultWaitRetryInterval = &retryInterval
+
+	t.Cleanup(func() {
+		transport.DefaultWaitRetryInterval = previousRetryInterval
+	})
+}
+
+func TestKubernetesClientCordonAndDrainNodes(t *testing.T) {
+	setTestRetryInterval(t, 0)
+
+	fakeServer := &fakeKubeAPIServer{
+		pods: map[string]string{
+			"app-1":   "node-1",
+			"app-2":   "node-2",
+			"ds-1":    "node-1",
+			"other-1": "node-3",
+		},
+		blockedEvictions: 2,
+	}
+
+	server := httptest.NewTLSServer(fakeServer)
+	defer server.Close()
+
+	caData := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	client, err := k8s.NewKubernetesClient(server.URL, caData, "token")
+	require.NoError(t, err)
+
+	err = client.CordonAndDrainNodes(context.Background(), []string{"node-1", "node-2"}, time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"node-1", "node-2"}, fakeServer.cordoned)
+	assert.ElementsMatch(t, []string{"app-1", "app-2"}, fakeServer.evicted)
+	assert.Contains(t, fakeServer.pods, "ds-1")
+	assert.Contains(t, fakeServer.pods, "other-1")
+
+	_, err = k8s.NewKubernetesClient(server.URL, "not base64", "token")
+	require.Error(t, err)
+
+	badTokenClient, err := k8s.NewKubernetesClient(server.URL, caData, "wrong")
+	require.NoError(t, err)
+	require.Error(t, badTokenClient.CordonNode(context.Background(), "node-1"))
+}
+
+func TestKubernetesClientCordonAndDrainNodesSharesTimeout(t *testing.T) {
+	setTestRetryInterval(t, 10*time.Millisecond)
+
+	fakeServer := &fakeKubeAPIServer{
+		pods: map[string]string{
+			"app-1": "node-1",
+			"app-2": "node-2",
+		},
+		blockedEvictions: 1 << 30,
+	}
+
+	server := httptest.NewTLSServer(fakeServer)
+	defer server.Close()
+
+	caData := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	client, err := k8s.NewKubernetesClient(server.URL, caData, "token")
+	require.NoError(t, err)
+
+	timeout := 300 * time.Millisecond
+	start := time.Now()
+
+	err = client.CordonAndDrainNodes(context.Background(), []string{"node-1", "node-2"}, timeout)
+	require.Error(t, err)
+
+	// Draining each node with its own timeout would take twice as long
+	assert.Less(t, time.Since(start), timeout+timeout*3/4)
+	assert.Empty(t, fakeServer.evicted)
+}
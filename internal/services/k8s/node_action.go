@@ -0,0 +1,196 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+const (
+	nodeActionReboot  = "reboot"
+	nodeActionReplace = "replace"
+)
+
+// ResourceNodeAction runs a one shot action on a node, the action is run again whenever the resource is replaced
+func ResourceNodeAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceK8SNodeActionCreate,
+		ReadContext:   ResourceK8SNodeActionRead,
+		DeleteContext: ResourceK8SNodeActionDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultK8SPoolTimeout),
+			Default: schema.DefaultTimeout(defaultK8SPoolTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"node_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The ID of the node on which the action is run",
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The action to run on the node, either reboot or replace",
+				ValidateFunc: validation.StringInSlice([]string{nodeActionReboot, nodeActionReplace}, false),
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, will run the action again",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"wait_for_node_ready": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether to wait for the node to be ready after the action",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the node",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the node, used to find a replaced node that came back with a new ID",
+			},
+			"pool_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the pool of the node",
+			},
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the cluster of the node",
+			},
+			"region": regional.Schema(),
+		},
+	}
+}
+
+func ResourceK8SNodeActionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	k8sAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeID := locality.ExpandID(d.Get("node_id"))
+
+	// The node must not be in a transient state for the action to be accepted
+	node, err := waitNodeReady(ctx, k8sAPI, region, nodeID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	switch action := d.Get("action").(string); action {
+	case nodeActionReboot:
+		_, err = k8sAPI.RebootNode(&k8s.RebootNodeRequest{
+			Region: region,
+			NodeID: nodeID,
+		}, scw.WithContext(ctx))
+	case nodeActionReplace:
+		_, err = k8sAPI.ReplaceNode(&k8s.ReplaceNodeRequest{ //nolint:staticcheck
+			Region: region,
+			NodeID: nodeID,
+		}, scw.WithContext(ctx))
+	default:
+		err = fmt.Errorf("unknown node action %q", action)
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(regional.NewIDString(region, nodeID))
+	setNodeActionNodeState(d, node)
+
+	if d.Get("wait_for_node_ready").(bool) {
+		node, err = WaitNodeActionDone(ctx, k8sAPI, node, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(regional.NewIDString(region, node.ID))
+	}
+
+	return ResourceK8SNodeActionRead(ctx, d, m)
+}
+
+func ResourceK8SNodeActionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	k8sAPI, region, nodeID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	node, err := k8sAPI.GetNode(&k8s.GetNodeRequest{
+		Region: region,
+		NodeID: nodeID,
+	}, scw.WithContext(ctx))
+	if httperrors.Is404(err) && d.Get("name").(string) != "" {
+		// A replaced node may come back with a new ID, the action must not be run again
+		node, err = findReplacedNode(ctx, k8sAPI, &k8s.Node{
+			ID:        nodeID,
+			Region:    region,
+			Name:      d.Get("name").(string),
+			PoolID:    locality.ExpandID(d.Get("pool_id")),
+			ClusterID: locality.ExpandID(d.Get("cluster_id")),
+		})
+		if err == nil && node == nil {
+			d.SetId("")
+
+			return nil
+		}
+	}
+
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	d.SetId(regional.NewIDString(region, node.ID))
+	setNodeActionNodeState(d, node)
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+// setNodeActionNodeState stores the attributes of the node, node_id is left untouched as the node may have been replaced
+func setNodeActionNodeState(d *schema.ResourceData, node *k8s.Node) {
+	_ = d.Set("status", node.Status.String())
+	_ = d.Set("name", node.Name)
+	_ = d.Set("pool_id", regional.NewIDString(node.Region, node.PoolID))
+	_ = d.Set("cluster_id", regional.NewIDString(node.Region, node.ClusterID))
+}
+
+// ResourceK8SNodeActionDelete only removes the action from the state, an action cannot be undone
+func ResourceK8SNodeActionDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
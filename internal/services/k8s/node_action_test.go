@@ -0,0 +1,257 @@
+package k8s_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	k8sSDK "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNodeAPIServer serves the nodes of the Kubernetes API of Scaleway, each GET of a node returns its next status
+type fakeNodeAPIServer struct {
+	mu sync.Mutex
+	// statuses maps a node ID to the statuses returned by successive GETs, the node is not found once they are consumed
+	statuses map[string][]k8sSDK.NodeStatus
+	// listed are the nodes returned when listing the nodes of the pool
+	listed []*k8sSDK.Node
+}
+
+func (f *fakeNodeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/k8s/v1/regions/fr-par/nodes/"):
+		nodeID := strings.TrimPrefix(r.URL.Path, "/k8s/v1/regions/fr-par/nodes/")
+
+		statuses := f.statuses[nodeID]
+		if len(statuses) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"type":"not_found","resource":"node","resource_id":"` + nodeID + `"}`))
+
+			return
+		}
+
+		f.statuses[nodeID] = statuses[1:]
+		_ = json.NewEncoder(w).Encode(testNode(nodeID, statuses[0]))
+	case r.Method == http.MethodGet && r.URL.Path == "/k8s/v1/regions/fr-par/clusters/cluster-id/nodes":
+		if r.URL.Query().Get("pool_id") != "pool-id" || r.URL.Query().Get("name") != "node-name" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(&k8sSDK.ListNodesResponse{
+			TotalCount: uint64(len(f.listed)),
+			Nodes:      f.listed,
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testNode(nodeID string, status k8sSDK.NodeStatus) *k8sSDK.Node {
+	return &k8sSDK.Node{
+		ID:        nodeID,
+		PoolID:    "pool-id",
+		ClusterID: "cluster-id",
+		Region:    scw.RegionFrPar,
+		Name:      "node-name",
+		Status:    status,
+	}
+}
+
+func newFakeNodeAPI(t *testing.T, fakeServer *fakeNodeAPIServer) *k8sSDK.API {
+	t.Helper()
+
+	server := httptest.NewServer(fakeServer)
+	t.Cleanup(server.Close)
+
+	client, err := scw.NewClient(scw.WithAPIURL(server.URL), scw.WithoutAuth(), scw.WithDefaultRegion(scw.RegionFrPar))
+	require.NoError(t, err)
+
+	return k8sSDK.NewAPI(client)
+}
+
+func TestWaitNodeActionDone(t *testing.T) {
+	setTestRetryInterval(t, 0)
+
+	t.Run("node still ready right after the action", func(t *testing.T) {
+		k8sAPI := newFakeNodeAPI(t, &fakeNodeAPIServer{
+			statuses: map[string][]k8sSDK.NodeStatus{
+				"node-id": {
+					k8sSDK.NodeStatusReady,
+					k8sSDK.NodeStatusRebooting,
+					k8sSDK.NodeStatusNotReady,
+					k8sSDK.NodeStatusReady,
+					// Only returned if the waiter did not stop at the previous ready status
+					k8sSDK.NodeStatusRebooting,
+				},
+			},
+		})
+
+		node, err := k8s.WaitNodeActionDone(context.Background(), k8sAPI, testNode("node-id", k8sSDK.NodeStatusReady), time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, "node-id", node.ID)
+		assert.Equal(t, k8sSDK.NodeStatusReady, node.Status)
+	})
+
+	t.Run("node replaced with a new ID", func(t *testing.T) {
+		k8sAPI := newFakeNodeAPI(t, &fakeNodeAPIServer{
+			statuses: map[string][]k8sSDK.NodeStatus{
+				"node-id": {
+					k8sSDK.NodeStatusReady,
+					k8sSDK.NodeStatusDeleting,
+				},
+			},
+			listed: []*k8sSDK.Node{
+				{ID: "other-id", Region: scw.RegionFrPar, Name: "node-name-2", Status: k8sSDK.NodeStatusReady},
+				{ID: "new-id", Region: scw.RegionFrPar, Name: "node-name", Status: k8sSDK.NodeStatusReady},
+			},
+		})
+
+		node, err := k8s.WaitNodeActionDone(context.Background(), k8sAPI, testNode("node-id", k8sSDK.NodeStatusReady), time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, "new-id", node.ID)
+	})
+
+	t.Run("node in error", func(t *testing.T) {
+		k8sAPI := newFakeNodeAPI(t, &fakeNodeAPIServer{
+			statuses: map[string][]k8sSDK.NodeStatus{
+				"node-id": {
+					k8sSDK.NodeStatusRebooting,
+					k8sSDK.NodeStatusCreationError,
+				},
+			},
+		})
+
+		_, err := k8s.WaitNodeActionDone(context.Background(), k8sAPI, testNode("node-id", k8sSDK.NodeStatusReady), time.Second)
+		require.ErrorContains(t, err, "creation_error")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		statuses := make([]k8sSDK.NodeStatus, 1000)
+		for i := range statuses {
+			statuses[i] = k8sSDK.NodeStatusReady
+		}
+
+		k8sAPI := newFakeNodeAPI(t, &fakeNodeAPIServer{
+			statuses: map[string][]k8sSDK.NodeStatus{"node-id": statuses},
+		})
+
+		_, err := k8s.WaitNodeActionDone(context.Background(), k8sAPI, testNode("node-id", k8sSDK.NodeStatusReady), 50*time.Millisecond)
+		require.ErrorContains(t, err, "timeout")
+	})
+}
+
+func TestAccNodeAction_Reboot(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestK8SVersion := testAccK8SClusterGetLatestK8SVersion(tt)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      testAccCheckK8SClusterDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckK8SNodeActionConfig(latestK8SVersion, "reboot", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_k8s_node_action.main", "status", "ready"),
+					resource.TestCheckResourceAttrPair("scaleway_k8s_node_action.main", "pool_id", "scaleway_k8s_pool.main", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_k8s_node_action.main", "name", "data.scaleway_k8s_nodes.main", "nodes.0.name"),
+				),
+			},
+			{
+				Config: testAccCheckK8SNodeActionConfig(latestK8SVersion, "reboot", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_k8s_node_action.main", "status", "ready"),
+					resource.TestCheckResourceAttr("scaleway_k8s_node_action.main", "triggers.run", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNodeAction_Replace(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestK8SVersion := testAccK8SClusterGetLatestK8SVersion(tt)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      testAccCheckK8SClusterDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckK8SNodeActionConfig(latestK8SVersion, "replace", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_k8s_node_action.main", "status", "ready"),
+					resource.TestCheckResourceAttrPair("scaleway_k8s_node_action.main", "name", "data.scaleway_k8s_nodes.main", "nodes.0.name"),
+				),
+			},
+			{
+				// The replaced node must be found again without running the action a second time
+				Config:   testAccCheckK8SNodeActionConfig(latestK8SVersion, "replace", "1"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCheckK8SNodeActionConfig(version string, action string, run string) string {
+	return fmt.Sprintf(`
+resource "scaleway_vpc_private_network" "main" {
+	name = "test-k8s-node-action"
+}
+
+resource "scaleway_k8s_cluster" "main" {
+	name                        = "test-k8s-node-action"
+	version                     = "%s"
+	cni                         = "cilium"
+	delete_additional_resources = true
+	private_network_id          = scaleway_vpc_private_network.main.id
+}
+
+resource "scaleway_k8s_pool" "main" {
+	cluster_id = scaleway_k8s_cluster.main.id
+	name       = "test-k8s-node-action"
+	node_type  = "gp1_xs"
+	size       = 1
+}
+
+data "scaleway_k8s_nodes" "main" {
+	cluster_id = scaleway_k8s_cluster.main.id
+	pool_id    = scaleway_k8s_pool.main.id
+}
+
+resource "scaleway_k8s_node_action" "main" {
+	node_id = data.scaleway_k8s_nodes.main.nodes.0.id
+	action  = "%s"
+
+	triggers = {
+		run = "%s"
+	}
+
+	lifecycle {
+		ignore_changes = [node_id]
+	}
+}`, version, action, run)
+}
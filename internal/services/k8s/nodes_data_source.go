@@ -0,0 +1,236 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ipamSDK "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func DataSourceNodes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceK8SNodesRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The ID of the cluster the nodes belong to",
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			},
+			"pool_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The ID of the pool to filter for",
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only nodes containing this substring in their name will be returned",
+			},
+			"status": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The status of the nodes to filter for",
+				ValidateDiagFunc: verify.ValidateEnum[k8s.NodeStatus](),
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of nodes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the node",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the node",
+						},
+						"pool_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the pool of the node",
+						},
+						"provider_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The underlying instance ID, prefixed by instance type and location information",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the node",
+						},
+						"error_message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Details of the error, if any occurred when managing the node",
+						},
+						"conditions": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "The conditions of the node, including the Node Problem Detector conditions",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"public_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The public IPv4 address of the node",
+						},
+						"public_ip_v6": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The public IPv6 address of the node",
+						},
+						"private_ips": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The private IP addresses of the node",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The ID of the IP address resource",
+									},
+									"address": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The private IP address",
+									},
+								},
+							},
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date and time of the creation of the node",
+						},
+						"updated_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date and time of the last update of the node",
+						},
+					},
+				},
+			},
+			"region": regional.Schema(),
+		},
+	}
+}
+
+func DataSourceK8SNodesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	k8sAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clusterID := locality.ExpandID(d.Get("cluster_id"))
+
+	req := &k8s.ListNodesRequest{
+		Region:    region,
+		ClusterID: clusterID,
+		Name:      types.ExpandStringPtr(d.Get("name")),
+		Status:    k8s.NodeStatus(d.Get("status").(string)),
+	}
+
+	if poolID, ok := d.GetOk("pool_id"); ok {
+		req.PoolID = types.ExpandStringPtr(locality.ExpandID(poolID))
+	}
+
+	res, err := k8sAPI.ListNodes(req, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ipamAPI := ipamSDK.NewAPI(meta.ExtractScwClient(m))
+	nodes := []interface{}(nil)
+
+	for _, node := range res.Nodes {
+		privateIPs, err := getNodePrivateIPs(ctx, ipamAPI, region, node.ProviderID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		rawNode := map[string]interface{}{
+			"id":            regional.NewIDString(region, node.ID),
+			"name":          node.Name,
+			"pool_id":       regional.NewIDString(region, node.PoolID),
+			"provider_id":   node.ProviderID,
+			"status":        node.Status.String(),
+			"error_message": types.FlattenStringPtr(node.ErrorMessage),
+			"private_ips":   privateIPs,
+			"created_at":    types.FlattenTime(node.CreatedAt),
+			"updated_at":    types.FlattenTime(node.UpdatedAt),
+		}
+
+		if node.Conditions != nil { //nolint:staticcheck
+			rawNode["conditions"] = *node.Conditions //nolint:staticcheck
+		}
+
+		if node.PublicIPV4 != nil && node.PublicIPV4.String() != types.NetIPNil { //nolint:staticcheck
+			rawNode["public_ip"] = node.PublicIPV4.String() //nolint:staticcheck
+		}
+
+		if node.PublicIPV6 != nil && node.PublicIPV6.String() != types.NetIPNil { //nolint:staticcheck
+			rawNode["public_ip_v6"] = node.PublicIPV6.String() //nolint:staticcheck
+		}
+
+		nodes = append(nodes, rawNode)
+	}
+
+	d.SetId(regional.NewIDString(region, clusterID))
+	_ = d.Set("nodes", nodes)
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+// getNodePrivateIPs returns the private IPs of the instance backing a node, identified by a provider ID
+// like scaleway://instance/fr-par-1/11111111-1111-1111-1111-111111111111
+func getNodePrivateIPs(ctx context.Context, ipamAPI *ipamSDK.API, region scw.Region, providerID string) ([]interface{}, error) {
+	providerIDParts := strings.Split(providerID, "/")
+	if len(providerIDParts) < 2 || providerIDParts[len(providerIDParts)-2] == "" {
+		return nil, nil
+	}
+
+	res, err := ipamAPI.ListIPs(&ipamSDK.ListIPsRequest{
+		Region:       region,
+		ResourceID:   scw.StringPtr(providerIDParts[len(providerIDParts)-1]),
+		ResourceType: ipamSDK.ResourceTypeInstanceServer,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	privateIPs := []interface{}(nil)
+
+	for _, ip := range res.IPs {
+		address, err := types.FlattenIPNet(ip.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		privateIPs = append(privateIPs, map[string]interface{}{
+			"id":      regional.NewIDString(region, ip.ID),
+			"address": address,
+		})
+	}
+
+	return privateIPs, nil
+}
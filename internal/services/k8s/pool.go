@@ -2,10 +2,13 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
@@ -49,7 +52,6 @@ func ResourcePool() *schema.Resource {
 			"node_type": {
 				Type:             schema.TypeString,
 				Required:         true,
-				ForceNew:         true,
 				Description:      "Server type of the pool servers",
 				DiffSuppressFunc: dsf.IgnoreCaseAndHyphen,
 			},
@@ -94,7 +96,6 @@ func ResourcePool() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Default:          k8s.RuntimeContainerd.String(),
-				ForceNew:         true,
 				Description:      "Container runtime for the pool",
 				ValidateDiagFunc: verify.ValidateEnum[k8s.Runtime](),
 			},
@@ -145,7 +146,6 @@ func ResourcePool() *schema.Resource {
 			"root_volume_type": {
 				Type:             schema.TypeString,
 				Optional:         true,
-				ForceNew:         true,
 				Computed:         true,
 				Description:      "System volume type of the nodes composing the pool",
 				ValidateDiagFunc: verify.ValidateEnum[k8s.PoolVolumeType](),
@@ -157,6 +157,13 @@ func ResourcePool() *schema.Resource {
 				Computed:    true,
 				Description: "The size of the system volume of the nodes in gigabyte",
 			},
+			"replacement_strategy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          poolReplacementStrategyRecreate,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{poolReplacementStrategyRecreate, poolReplacementStrategyBlueGreen}, false)),
+				Description:      "How the pool is replaced when node_type, root_volume_type or container_runtime change: recreate destroys the pool before creating a new one, blue_green creates a new pool and drains the old nodes before deleting the old pool",
+			},
 			"public_ip_disabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -236,10 +243,51 @@ func ResourceK8SPoolCreate(ctx context.Context, d *schema.ResourceData, m interf
 	////
 	// Create pool
 	////
+	req := expandK8SPoolCreateRequest(d, region, types.ExpandOrGenerateString(d.Get("name"), "pool"))
+
+	// check if the cluster is waiting for a pool
+	cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
+		ClusterID: locality.ExpandID(d.Get("cluster_id")),
+		Region:    region,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if cluster.Status == k8s.ClusterStatusCreating {
+		_, err = waitClusterStatus(ctx, k8sAPI, cluster, k8s.ClusterStatusReady, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	res, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(regional.NewIDString(region, res.ID))
+
+	if d.Get("wait_for_pool_ready").(bool) { // wait for the pool to be ready if specified (including all its nodes)
+		_, err = waitPoolReady(ctx, k8sAPI, region, res.ID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	_, err = waitCluster(ctx, k8sAPI, region, cluster.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceK8SPoolRead(ctx, d, m)
+}
+
+func expandK8SPoolCreateRequest(d *schema.ResourceData, region scw.Region, name string) *k8s.CreatePoolRequest {
 	req := &k8s.CreatePoolRequest{
 		Region:           region,
 		ClusterID:        locality.ExpandID(d.Get("cluster_id")),
-		Name:             types.ExpandOrGenerateString(d.Get("name"), "pool"),
+		Name:             name,
 		NodeType:         d.Get("node_type").(string),
 		Autoscaling:      d.Get("autoscaling").(bool),
 		Autohealing:      d.Get("autohealing").(bool),
@@ -297,42 +345,7 @@ func ResourceK8SPoolCreate(ctx context.Context, d *schema.ResourceData, m interf
 		req.RootVolumeSize = &volumeSizeInBytes
 	}
 
-	// check if the cluster is waiting for a pool
-	cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
-		ClusterID: locality.ExpandID(d.Get("cluster_id")),
-		Region:    region,
-	}, scw.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if cluster.Status == k8s.ClusterStatusCreating {
-		_, err = waitClusterStatus(ctx, k8sAPI, cluster, k8s.ClusterStatusReady, d.Timeout(schema.TimeoutCreate))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	res, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	d.SetId(regional.NewIDString(region, res.ID))
-
-	if d.Get("wait_for_pool_ready").(bool) { // wait for the pool to be ready if specified (including all its nodes)
-		_, err = waitPoolReady(ctx, k8sAPI, region, res.ID, d.Timeout(schema.TimeoutCreate))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	_, err = waitCluster(ctx, k8sAPI, region, cluster.ID, d.Timeout(schema.TimeoutCreate))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	return ResourceK8SPoolRead(ctx, d, m)
+	return req
 }
 
 func ResourceK8SPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -366,7 +379,7 @@ func ResourceK8SPoolRead(ctx context.Context, d *schema.ResourceData, m interfac
 	}
 
 	_ = d.Set("cluster_id", regional.NewIDString(region, pool.ClusterID))
-	_ = d.Set("name", pool.Name)
+	_ = d.Set("name", poolBaseName(d.Get("name").(string), pool.Name))
 	_ = d.Set("node_type", pool.NodeType)
 	_ = d.Set("autoscaling", pool.Autoscaling)
 	_ = d.Set("autohealing", pool.Autohealing)
@@ -410,6 +423,15 @@ func ResourceK8SPoolUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 
+	if d.Get("replacement_strategy").(string) == poolReplacementStrategyBlueGreen && d.HasChanges(poolReplacementFields...) {
+		err = replaceK8SPoolBlueGreen(ctx, k8sAPI, region, poolID, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return ResourceK8SPoolRead(ctx, d, m)
+	}
+
 	////
 	// Update Pool
 	////
@@ -516,5 +538,113 @@ func ResourceK8SPoolCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ i
 		}
 	}
 
+	for _, field := range poolReplacementFields {
+		if !diff.HasChange(field) || diff.Id() == "" {
+			continue
+		}
+
+		if diff.Get("replacement_strategy").(string) != poolReplacementStrategyBlueGreen {
+			err := diff.ForceNew(field)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// The new pool has a new ID and new nodes
+		for _, computedField := range []string{"nodes", "created_at", "updated_at", "version", "status", "current_size"} {
+			err := diff.SetNewComputed(computedField)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// replaceK8SPoolBlueGreen creates a new pool with the planned configuration, waits for it to be ready,
+// cordons and drains the nodes of the old pool through the Kubernetes API then deletes the old pool
+func replaceK8SPoolBlueGreen(ctx context.Context, k8sAPI *k8s.API, region scw.Region, oldPoolID string, d *schema.ResourceData) error {
+	// Every step shares the update timeout
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutUpdate))
+
+	oldPool, err := k8sAPI.GetPool(&k8s.GetPoolRequest{
+		Region: region,
+		PoolID: oldPoolID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	req := expandK8SPoolCreateRequest(d, region, blueGreenPoolName(d.Get("name").(string), oldPool.Name))
+	req.ClusterID = oldPool.ClusterID
+
+	tflog.Info(ctx, fmt.Sprintf("creating pool %s to replace pool %s", req.Name, oldPool.Name))
+
+	newPool, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to create replacement pool %s: %w", req.Name, err)
+	}
+
+	// From now on the new pool is the one managed by terraform, so that it is not lost if the replacement fails
+	d.SetId(regional.NewIDString(region, newPool.ID))
+
+	_, err = waitPoolReady(ctx, k8sAPI, region, newPool.ID, time.Until(deadline))
+	if err != nil {
+		return fmt.Errorf("replacement pool %s is not ready, pool %s has been kept: %w", req.Name, oldPool.Name, err)
+	}
+
+	nodes, err := k8sAPI.ListNodes(&k8s.ListNodesRequest{
+		Region:    region,
+		ClusterID: oldPool.ClusterID,
+		PoolID:    &oldPool.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if len(nodes.Nodes) > 0 {
+		kubeconfig, err := flattenKubeconfig(ctx, k8sAPI, region, oldPool.ClusterID)
+		if err != nil {
+			return err
+		}
+
+		kubeClient, err := NewKubernetesClient(kubeconfig["host"].(string), kubeconfig["cluster_ca_certificate"].(string), kubeconfig["token"].(string))
+		if err != nil {
+			return err
+		}
+
+		nodeNames := make([]string, 0, len(nodes.Nodes))
+		for _, node := range nodes.Nodes {
+			nodeNames = append(nodeNames, node.Name)
+		}
+
+		err = kubeClient.CordonAndDrainNodes(ctx, nodeNames, time.Until(deadline))
+		if err != nil {
+			return fmt.Errorf("failed to drain pool %s, it must be deleted manually once drained: %w", oldPool.Name, err)
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("deleting replaced pool %s", oldPool.Name))
+
+	_, err = k8sAPI.DeletePool(&k8s.DeletePoolRequest{
+		Region: region,
+		PoolID: oldPool.ID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return err
+	}
+
+	_, err = k8sAPI.WaitForPool(&k8s.WaitForPoolRequest{
+		PoolID:  oldPool.ID,
+		Region:  region,
+		Timeout: scw.TimeDurationPtr(time.Until(deadline)),
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return err
+	}
+
 	return nil
 }
@@ -2,11 +2,14 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 )
 
 func clusterAutoscalerConfigFlatten(cluster *k8s.Cluster) []map[string]interface{} {
@@ -117,3 +120,85 @@ func flattenKubeconfig(ctx context.Context, k8sAPI *k8s.API, region scw.Region,
 
 	return kubeconf, nil
 }
+
+// k8sACLRuleHash ignores the computed rule ID so that rules are identified by their content,
+// the IP is normalized as the API returns single addresses as /32 or /128 networks
+func k8sACLRuleHash(v interface{}) int {
+	rule := v.(map[string]interface{})
+	ip, _ := rule["ip"].(string)
+
+	return schema.HashString(fmt.Sprintf("%v-%v-%v", NormalizeACLRuleIP(ip), rule["scaleway_ranges"], rule["description"]))
+}
+
+// NormalizeACLRuleIP returns the CIDR notation of an ACL rule IP, a single address is a /32 or /128 network
+func NormalizeACLRuleIP(ip string) string {
+	if ip == "" {
+		return ""
+	}
+
+	ipNet, err := types.ExpandIPNet(ip)
+	if err != nil {
+		return ip
+	}
+
+	return ipNet.String()
+}
+
+func expandK8SACLRules(rawRules []interface{}) ([]*k8s.ACLRuleRequest, error) {
+	rules := make([]*k8s.ACLRuleRequest, 0, len(rawRules))
+
+	for _, rawRule := range rawRules {
+		rule := rawRule.(map[string]interface{})
+		ip := rule["ip"].(string)
+		scalewayRanges := rule["scaleway_ranges"].(bool)
+
+		if (ip == "") == !scalewayRanges {
+			return nil, errors.New("each ACL rule must set exactly one of ip or scaleway_ranges")
+		}
+
+		request := &k8s.ACLRuleRequest{
+			Description: rule["description"].(string),
+		}
+
+		if scalewayRanges {
+			request.ScalewayRanges = scw.BoolPtr(true)
+		} else {
+			ipNet, err := types.ExpandIPNet(ip)
+			if err != nil {
+				return nil, err
+			}
+
+			request.IP = &ipNet
+		}
+
+		rules = append(rules, request)
+	}
+
+	return rules, nil
+}
+
+func flattenK8SACLRules(rules []*k8s.ACLRule) ([]interface{}, error) {
+	flattenedRules := make([]interface{}, 0, len(rules))
+
+	for _, rule := range rules {
+		flattenedRule := map[string]interface{}{
+			"id":              rule.ID,
+			"description":     rule.Description,
+			"scaleway_ranges": rule.ScalewayRanges != nil && *rule.ScalewayRanges,
+			"ip":              "",
+		}
+
+		if rule.IP != nil {
+			ip, err := types.FlattenIPNet(*rule.IP)
+			if err != nil {
+				return nil, err
+			}
+
+			flattenedRule["ip"] = ip
+		}
+
+		flattenedRules = append(flattenedRules, flattenedRule)
+	}
+
+	return flattenedRules, nil
+}
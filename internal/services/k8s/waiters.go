@@ -87,3 +87,127 @@ func waitPoolReady(ctx context.Context, k8sAPI *k8s.API, region scw.Region, pool
 
 	return pool, nil
 }
+
+// waitPoolUpgraded waits for the upgrade of a pool to be over. The pool may still be ready right after
+// the upgrade request, so a pool with nodes must leave the ready status before being ready in the target version.
+func waitPoolUpgraded(ctx context.Context, k8sAPI *k8s.API, pool *k8s.Pool, version string, timeout time.Duration) (*k8s.Pool, error) {
+	retryInterval := defaultK8SRetryInterval
+	if transport.DefaultWaitRetryInterval != nil {
+		retryInterval = *transport.DefaultWaitRetryInterval
+	}
+
+	deadline := time.Now().Add(timeout)
+	started := pool.Status != k8s.PoolStatusReady || pool.Size == 0
+
+	for !started {
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout waiting for the upgrade of pool %s to start", pool.ID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryInterval):
+		}
+
+		var err error
+
+		pool, err = k8sAPI.GetPool(&k8s.GetPoolRequest{
+			Region: pool.Region,
+			PoolID: pool.ID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		started = pool.Status != k8s.PoolStatusReady
+	}
+
+	pool, err := waitPoolReady(ctx, k8sAPI, pool.Region, pool.ID, time.Until(deadline))
+	if err != nil {
+		return nil, err
+	}
+
+	if !k8sVersionMatches(pool.Version, version) {
+		return nil, fmt.Errorf("pool %s is ready in version %s instead of %s", pool.ID, pool.Version, version)
+	}
+
+	return pool, nil
+}
+
+func waitNodeReady(ctx context.Context, k8sAPI *k8s.API, region scw.Region, nodeID string, timeout time.Duration) (*k8s.Node, error) {
+	retryInterval := defaultK8SRetryInterval
+	if transport.DefaultWaitRetryInterval != nil {
+		retryInterval = *transport.DefaultWaitRetryInterval
+	}
+
+	node, err := k8sAPI.WaitForNode(&k8s.WaitForNodeRequest{
+		NodeID:        nodeID,
+		Region:        region,
+		Timeout:       scw.TimeDurationPtr(timeout),
+		RetryInterval: &retryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if node.Status != k8s.NodeStatusReady {
+		return nil, fmt.Errorf("node %s has state %s, wants %s", nodeID, node.Status, k8s.NodeStatusReady)
+	}
+
+	return node, nil
+}
+
+// WaitNodeActionDone waits for a node to be ready again after a reboot or a replacement. The node may still be ready
+// right after the request, and a replaced node may come back with a new ID, in which case it is looked up by name in its pool.
+func WaitNodeActionDone(ctx context.Context, k8sAPI *k8s.API, node *k8s.Node, timeout time.Duration) (*k8s.Node, error) {
+	retryInterval := defaultK8SRetryInterval
+	if transport.DefaultWaitRetryInterval != nil {
+		retryInterval = *transport.DefaultWaitRetryInterval
+	}
+
+	deadline := time.Now().Add(timeout)
+	started := false
+
+	for {
+		current, err := k8sAPI.GetNode(&k8s.GetNodeRequest{
+			Region: node.Region,
+			NodeID: node.ID,
+		}, scw.WithContext(ctx))
+
+		switch {
+		case httperrors.Is404(err):
+			started = true
+
+			current, err = findReplacedNode(ctx, k8sAPI, node)
+			if err != nil {
+				return nil, err
+			}
+		case err != nil:
+			return nil, err
+		}
+
+		if current != nil {
+			switch current.Status {
+			case k8s.NodeStatusReady:
+				if started {
+					return current, nil
+				}
+			case k8s.NodeStatusCreationError, k8s.NodeStatusLocked:
+				return nil, fmt.Errorf("node %s has state %s, wants %s", current.ID, current.Status, k8s.NodeStatusReady)
+			default:
+				started = true
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout waiting for the action on node %s to be done", node.ID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryInterval):
+		}
+	}
+}
@@ -0,0 +1,538 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+const (
+	lbACLSetDefaultDenyName = "default-deny"
+	lbACLSetChunkSize       = 50
+	// lbACLSetIndexStep is the gap left between the indexes of new ACLs, so that inserting an ACL does not shift the following ones
+	lbACLSetIndexStep = 10
+)
+
+// lbACLSetChunkNameRegex matches the names generated for the chunks of a rule, "<rule>-<hash>"
+var lbACLSetChunkNameRegex = regexp.MustCompile(`^(.+)-[0-9a-f]{8}$`)
+
+func ResourceACLSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLbACLSetCreate,
+		ReadContext:   resourceLbACLSetRead,
+		UpdateContext: resourceLbACLSetUpdate,
+		DeleteContext: resourceLbACLSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLbACLSetImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultLbLbTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"frontend_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				Description:      "The frontend ID on which the ACLs are applied",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Ordered list of rules, each rule is split into ACLs matching at most chunk_size IPs",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name of the rule, used as a prefix for the name of its ACLs",
+							ValidateFunc: validation.StringNotInSlice([]string{lbACLSetDefaultDenyName}, false),
+						},
+						"action": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      lbSDK.ACLActionTypeAllow.String(),
+							ValidateFunc: validation.StringInSlice([]string{lbSDK.ACLActionTypeAllow.String(), lbSDK.ACLActionTypeDeny.String()}, false),
+							Description:  "The action to undertake when a client IP matches the rule",
+						},
+						"ip_subnet": {
+							Type: schema.TypeList,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Required:         true,
+							MinItems:         1,
+							Description:      "A list of IPs or CIDR v4/v6 addresses of the client of the session to match",
+							DiffSuppressFunc: diffSuppressFunc32SubnetMask,
+						},
+					},
+				},
+			},
+			"chunk_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      lbACLSetChunkSize,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of IPs matched by a single ACL",
+			},
+			"default_deny": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Add a last ACL denying every client not matched by a previous rule",
+			},
+			"acl_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of the ACLs of the set, ordered by index",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceLbACLSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	lbAPI, _, err := lbAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	zone, frontendID, err := zonal.ParseID(d.Get("frontend_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the ID is set first so that the ACLs created before a failure are tracked in the state
+	d.SetId(zonal.NewIDString(zone, frontendID))
+
+	err = applyLbACLSet(ctx, d, lbAPI, zone, frontendID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceLbACLSetRead(ctx, d, m)
+}
+
+func resourceLbACLSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	lbAPI, zone, frontendID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := lbAPI.ListACLs(&lbSDK.ZonedAPIListACLsRequest{
+		Zone:       zone,
+		FrontendID: frontendID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	rules, defaultDeny, aclIDs := flattenLbACLSet(filterLbACLSetACLs(res.ACLs, types.ExpandStrings(d.Get("acl_ids"))))
+
+	_ = d.Set("frontend_id", zonal.NewIDString(zone, frontendID))
+	_ = d.Set("rule", rules)
+	_ = d.Set("default_deny", defaultDeny)
+	_ = d.Set("acl_ids", aclIDs)
+
+	return nil
+}
+
+func resourceLbACLSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	lbAPI, zone, frontendID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("rule", "chunk_size", "default_deny") {
+		err = applyLbACLSet(ctx, d, lbAPI, zone, frontendID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceLbACLSetRead(ctx, d, m)
+}
+
+func resourceLbACLSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	lbAPI, zone, _, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, aclID := range types.ExpandStrings(d.Get("acl_ids")) {
+		err = lbAPI.DeleteACL(&lbSDK.ZonedAPIDeleteACLRequest{
+			Zone:  zone,
+			ACLID: aclID,
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// resourceLbACLSetImport seeds acl_ids with the ACLs of the frontend named like the ones a set generates,
+// the ACLs of the set are then only tracked through acl_ids
+func resourceLbACLSetImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	lbAPI, zone, frontendID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := lbAPI.ListACLs(&lbSDK.ZonedAPIListACLsRequest{
+		Zone:       zone,
+		FrontendID: frontendID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	aclIDs := []string(nil)
+
+	for _, acl := range res.ACLs {
+		if acl.Name == lbACLSetDefaultDenyName || lbACLSetChunkNameRegex.MatchString(acl.Name) {
+			aclIDs = append(aclIDs, acl.ID)
+		}
+	}
+
+	_ = d.Set("acl_ids", aclIDs)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyLbACLSet reconciles the ACLs of the set with its rules, the other ACLs of the frontend are left untouched.
+// The ACLs of the set are the ones listed in acl_ids, they are matched by name so that only the chunks whose content changed are updated
+func applyLbACLSet(ctx context.Context, d *schema.ResourceData, lbAPI *lbSDK.ZonedAPI, zone scw.Zone, frontendID string) error {
+	desiredACLs, err := expandLbACLSet(d.Get("rule"), d.Get("chunk_size").(int), d.Get("default_deny").(bool))
+	if err != nil {
+		return err
+	}
+
+	res, err := lbAPI.ListACLs(&lbSDK.ZonedAPIListACLsRequest{
+		Zone:       zone,
+		FrontendID: frontendID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	apiACLs := make(map[string]*lbSDK.ACL, len(res.ACLs))
+	for _, acl := range filterLbACLSetACLs(res.ACLs, types.ExpandStrings(d.Get("acl_ids"))) {
+		apiACLs[acl.Name] = acl
+	}
+
+	AssignLbACLSetIndexes(desiredACLs, apiACLs)
+
+	aclIDs := make([]string, 0, len(desiredACLs))
+
+	// acl_ids is kept up to date as ACLs are created, so that a failed apply does not lose track of them
+	defer func() {
+		for _, acl := range apiACLs {
+			aclIDs = append(aclIDs, acl.ID)
+		}
+
+		_ = d.Set("acl_ids", aclIDs)
+	}()
+
+	for _, desiredACL := range desiredACLs {
+		if apiACL, found := apiACLs[desiredACL.Name]; found {
+			delete(apiACLs, desiredACL.Name)
+			aclIDs = append(aclIDs, apiACL.ID)
+
+			if lbACLSetEquals(desiredACL, apiACL) {
+				continue
+			}
+
+			_, err = lbAPI.UpdateACL(&lbSDK.ZonedAPIUpdateACLRequest{
+				Zone:   zone,
+				ACLID:  apiACL.ID,
+				Name:   desiredACL.Name,
+				Action: desiredACL.Action,
+				Match:  desiredACL.Match,
+				Index:  desiredACL.Index,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		acl, err := lbAPI.CreateACL(&lbSDK.ZonedAPICreateACLRequest{
+			Zone:       zone,
+			FrontendID: frontendID,
+			Name:       desiredACL.Name,
+			Action:     desiredACL.Action,
+			Match:      desiredACL.Match,
+			Index:      desiredACL.Index,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		aclIDs = append(aclIDs, acl.ID)
+	}
+
+	for name, acl := range apiACLs {
+		err = lbAPI.DeleteACL(&lbSDK.ZonedAPIDeleteACLRequest{
+			Zone:  zone,
+			ACLID: acl.ID,
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			return err
+		}
+
+		delete(apiACLs, name)
+	}
+
+	return nil
+}
+
+// expandLbACLSet generates the ordered ACLs of the rules, their indexes are assigned by AssignLbACLSetIndexes
+func expandLbACLSet(rawRules interface{}, chunkSize int, defaultDeny bool) ([]*lbSDK.ACL, error) {
+	acls := []*lbSDK.ACL(nil)
+	ruleNames := make(map[string]struct{})
+
+	for _, rawRule := range rawRules.([]interface{}) {
+		rule := rawRule.(map[string]interface{})
+		name := rule["name"].(string)
+
+		if _, found := ruleNames[name]; found {
+			return nil, fmt.Errorf("rule name %s is used more than once", name)
+		}
+
+		ruleNames[name] = struct{}{}
+
+		subnets := types.ExpandStrings(rule["ip_subnet"])
+
+		chunks, err := ChunkACLSubnets(subnets, chunkSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %s: %w", name, err)
+		}
+
+		for _, chunk := range chunks {
+			acls = append(acls, &lbSDK.ACL{
+				Name: lbACLSetChunkName(name, chunk),
+				Action: &lbSDK.ACLAction{
+					Type: lbSDK.ACLActionType(rule["action"].(string)),
+				},
+				Match: &lbSDK.ACLMatch{
+					IPSubnet:   scw.StringSlicePtr(chunk),
+					HTTPFilter: lbSDK.ACLHTTPFilterACLHTTPFilterNone,
+				},
+			})
+		}
+	}
+
+	if defaultDeny {
+		acls = append(acls, &lbSDK.ACL{
+			Name: lbACLSetDefaultDenyName,
+			Action: &lbSDK.ACLAction{
+				Type: lbSDK.ACLActionTypeDeny,
+			},
+			Match: &lbSDK.ACLMatch{
+				IPSubnet:   scw.StringSlicePtr([]string{"0.0.0.0/0", "::/0"}),
+				HTTPFilter: lbSDK.ACLHTTPFilterACLHTTPFilterNone,
+			},
+		})
+	}
+
+	return acls, nil
+}
+
+// filterLbACLSetACLs returns the ACLs of the frontend belonging to the set, the ones listed in its acl_ids
+func filterLbACLSetACLs(acls []*lbSDK.ACL, aclIDs []string) []*lbSDK.ACL {
+	setACLs := []*lbSDK.ACL(nil)
+
+	for _, acl := range acls {
+		if slices.Contains(aclIDs, acl.ID) {
+			setACLs = append(setACLs, acl)
+		}
+	}
+
+	return setACLs
+}
+
+// AssignLbACLSetIndexes sets the index of the desired ACLs, keeping the index of the existing ACLs when the order allows it.
+// New ACLs are placed in the gap left before the next existing ACL, the following ACLs are only shifted when there is no gap left
+func AssignLbACLSetIndexes(desiredACLs []*lbSDK.ACL, existingACLs map[string]*lbSDK.ACL) {
+	previousIndex := int32(0)
+
+	for i, acl := range desiredACLs {
+		if existingACL, found := existingACLs[acl.Name]; found && existingACL.Index > previousIndex {
+			acl.Index = existingACL.Index
+			previousIndex = acl.Index
+
+			continue
+		}
+
+		acl.Index = previousIndex + lbACLSetIndexStep
+
+		// look for the next ACL keeping its index to place this one in the gap before it
+		for j := i + 1; j < len(desiredACLs); j++ {
+			nextACL, found := existingACLs[desiredACLs[j].Name]
+			if !found || nextACL.Index <= previousIndex {
+				continue
+			}
+
+			if gap := nextACL.Index - previousIndex; gap > int32(j-i) {
+				acl.Index = previousIndex + gap/int32(j-i+1)
+			}
+
+			break
+		}
+
+		previousIndex = acl.Index
+	}
+}
+
+// flattenLbACLSet groups the ACLs of a frontend back into rules using the name prefix of their chunks
+func flattenLbACLSet(acls []*lbSDK.ACL) ([]map[string]interface{}, bool, []string) {
+	sort.Slice(acls, func(i, j int) bool {
+		return acls[i].Index < acls[j].Index
+	})
+
+	rules := []map[string]interface{}(nil)
+	defaultDeny := false
+	aclIDs := make([]string, 0, len(acls))
+
+	for _, acl := range acls {
+		aclIDs = append(aclIDs, acl.ID)
+
+		if acl.Name == lbACLSetDefaultDenyName {
+			defaultDeny = true
+
+			continue
+		}
+
+		subnets := lbACLSetSubnets(acl)
+
+		action := lbSDK.ACLActionTypeAllow.String()
+		if acl.Action != nil {
+			action = acl.Action.Type.String()
+		}
+
+		name := acl.Name
+		if matches := lbACLSetChunkNameRegex.FindStringSubmatch(name); matches != nil {
+			name = matches[1]
+		}
+
+		if len(rules) > 0 && rules[len(rules)-1]["name"] == name {
+			lastRule := rules[len(rules)-1]
+			lastRule["ip_subnet"] = append(lastRule["ip_subnet"].([]string), subnets...)
+
+			continue
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"name":      name,
+			"action":    action,
+			"ip_subnet": subnets,
+		})
+	}
+
+	return rules, defaultDeny, aclIDs
+}
+
+func lbACLSetSubnets(acl *lbSDK.ACL) []string {
+	subnets := []string(nil)
+	if acl.Match == nil {
+		return subnets
+	}
+
+	for _, subnet := range acl.Match.IPSubnet {
+		if subnet != nil {
+			subnets = append(subnets, *subnet)
+		}
+	}
+
+	return subnets
+}
+
+// lbACLSetEquals compares the fields managed by the set, the API may drop the /32 mask of the subnets
+func lbACLSetEquals(desiredACL, apiACL *lbSDK.ACL) bool {
+	if desiredACL.Index != apiACL.Index || apiACL.Action == nil || desiredACL.Action.Type != apiACL.Action.Type {
+		return false
+	}
+
+	if apiACL.Match == nil || apiACL.Match.Invert || apiACL.Match.HTTPFilter != lbSDK.ACLHTTPFilterACLHTTPFilterNone {
+		return false
+	}
+
+	return slices.Equal(normalizeIPSubnetList(lbACLSetSubnets(desiredACL)), normalizeIPSubnetList(lbACLSetSubnets(apiACL)))
+}
+
+// lbACLSetChunkName names a chunk after its rule and its first entry so that it keeps its name when other chunks change
+func lbACLSetChunkName(ruleName string, chunk []string) string {
+	return fmt.Sprintf("%s-%08x", ruleName, lbACLSetHash(chunk[0]))
+}
+
+func lbACLSetHash(entry string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(normalizeIPSubnet(entry)))
+
+	return h.Sum32()
+}
+
+// ChunkACLSubnets splits the subnets in chunks of at most chunkSize entries, keeping their order.
+// Chunk boundaries depend on the content of the entries so that adding or removing an entry only changes its own chunk
+func ChunkACLSubnets(subnets []string, chunkSize int) ([][]string, error) {
+	if chunkSize < 1 {
+		return nil, fmt.Errorf("chunk size must be at least 1, got %d", chunkSize)
+	}
+
+	seen := make(map[string]struct{}, len(subnets))
+	for _, subnet := range subnets {
+		normalized := normalizeIPSubnet(subnet)
+		if _, found := seen[normalized]; found {
+			return nil, fmt.Errorf("ip_subnet %s is listed more than once", subnet)
+		}
+
+		seen[normalized] = struct{}{}
+	}
+
+	// Boundaries are placed on average every half chunk to rarely reach the maximum size
+	boundaryModulo := uint32(max(chunkSize/2, 1))
+
+	chunks := [][]string(nil)
+	chunk := []string(nil)
+
+	for _, subnet := range subnets {
+		chunk = append(chunk, subnet)
+
+		if len(chunk) >= chunkSize || lbACLSetHash(subnet)%boundaryModulo == 0 {
+			chunks = append(chunks, chunk)
+			chunk = nil
+		}
+	}
+
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks, nil
+}
@@ -0,0 +1,296 @@
+package lb_test
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/lb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkACLSubnets(t *testing.T) {
+	subnets := make([]string, 0, 300)
+	for i := range 300 {
+		subnets = append(subnets, fmt.Sprintf("10.%d.%d.0/24", i/256, i%256))
+	}
+
+	chunks, err := lb.ChunkACLSubnets(subnets, 50)
+	require.NoError(t, err)
+
+	flattened := []string(nil)
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, len(chunk), 50)
+		flattened = append(flattened, chunk...)
+	}
+
+	assert.Equal(t, subnets, flattened)
+
+	// Adding an entry in the middle only changes the chunk it lands in
+	updatedSubnets := slices.Insert(slices.Clone(subnets), 150, "192.168.0.0/16")
+	updatedChunks, err := lb.ChunkACLSubnets(updatedSubnets, 50)
+	require.NoError(t, err)
+
+	changed := 0
+
+	for _, chunk := range updatedChunks {
+		if !slices.ContainsFunc(chunks, func(c []string) bool { return slices.Equal(c, chunk) }) {
+			changed++
+		}
+	}
+
+	assert.LessOrEqual(t, changed, 2)
+
+	_, err = lb.ChunkACLSubnets([]string{"10.0.0.1", "10.0.0.1/32"}, 50)
+	require.Error(t, err)
+
+	_, err = lb.ChunkACLSubnets(subnets, 0)
+	require.Error(t, err)
+}
+
+func TestAssignLbACLSetIndexes(t *testing.T) {
+	newACLs := func(names ...string) []*lbSDK.ACL {
+		acls := make([]*lbSDK.ACL, 0, len(names))
+		for _, name := range names {
+			acls = append(acls, &lbSDK.ACL{Name: name})
+		}
+
+		return acls
+	}
+
+	indexes := func(acls []*lbSDK.ACL) []int32 {
+		result := make([]int32, 0, len(acls))
+		for _, acl := range acls {
+			result = append(result, acl.Index)
+		}
+
+		return result
+	}
+
+	// New ACLs are spaced to leave room for insertions
+	acls := newACLs("a", "b", "c")
+	lb.AssignLbACLSetIndexes(acls, nil)
+	assert.Equal(t, []int32{10, 20, 30}, indexes(acls))
+
+	existing := map[string]*lbSDK.ACL{
+		"a": {Name: "a", Index: 10},
+		"b": {Name: "b", Index: 20},
+		"c": {Name: "c", Index: 30},
+	}
+
+	// Inserting in the middle keeps the index of the other ACLs
+	acls = newACLs("a", "x", "y", "b", "c", "z")
+	lb.AssignLbACLSetIndexes(acls, existing)
+	assert.Equal(t, []int32{10, 13, 16, 20, 30, 40}, indexes(acls))
+
+	// Without a gap left, the following ACLs are shifted
+	existing = map[string]*lbSDK.ACL{
+		"a": {Name: "a", Index: 1},
+		"b": {Name: "b", Index: 2},
+		"c": {Name: "c", Index: 3},
+	}
+	acls = newACLs("a", "x", "b", "c")
+	lb.AssignLbACLSetIndexes(acls, existing)
+	assert.Equal(t, []int32{1, 11, 21, 31}, indexes(acls))
+
+	// Reordering keeps the indexes increasing
+	existing = map[string]*lbSDK.ACL{
+		"a": {Name: "a", Index: 10},
+		"b": {Name: "b", Index: 20},
+		"c": {Name: "c", Index: 30},
+	}
+	acls = newACLs("c", "a", "b")
+	lb.AssignLbACLSetIndexes(acls, existing)
+	assert.Equal(t, []int32{30, 40, 50}, indexes(acls))
+}
+
+func testAccACLSetConfig(subnets []string) string {
+	return fmt.Sprintf(`
+		resource scaleway_lb_ip ip01 {}
+		resource scaleway_lb lb01 {
+			ip_id = scaleway_lb_ip.ip01.id
+			name = "test-lb-acl-set"
+			type = "lb-s"
+		}
+		resource scaleway_lb_backend bkd01 {
+			lb_id = scaleway_lb.lb01.id
+			forward_protocol = "http"
+			forward_port = 80
+			proxy_protocol = "none"
+		}
+		resource scaleway_lb_frontend frt01 {
+			lb_id = scaleway_lb.lb01.id
+			backend_id = scaleway_lb_backend.bkd01.id
+			inbound_port = 80
+			external_acls = true
+		}
+		resource scaleway_lb_acl manual {
+			frontend_id = scaleway_lb_frontend.frt01.id
+			name = "manual"
+			index = 1000
+			action {
+				type = "deny"
+			}
+			match {
+				ip_subnet = ["198.51.100.0/24"]
+			}
+		}
+		resource scaleway_lb_acl_set allowlist {
+			frontend_id = scaleway_lb_acl.manual.frontend_id
+			chunk_size = 4
+
+			rule {
+				name = "office"
+				ip_subnet = ["%s"]
+			}
+		}
+	`, strings.Join(subnets, `", "`))
+}
+
+func TestAccACLSet_InsertEntry(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	subnets := make([]string, 0, 16)
+	for i := range 16 {
+		subnets = append(subnets, "10.0."+strconv.Itoa(i)+".0/24")
+	}
+
+	updatedSubnets := slices.Insert(slices.Clone(subnets), 8, "192.168.0.0/16")
+
+	// initialACLs holds the ACLs of the set after the first step, by ID
+	initialACLs := map[string]*lbSDK.ACL{}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      isACLDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccACLSetConfig(subnets),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_lb_acl_set.allowlist", "rule.0.ip_subnet.#", "16"),
+					resource.TestCheckResourceAttr("scaleway_lb_acl_set.allowlist", "default_deny", "true"),
+					isACLPresent(tt, "scaleway_lb_acl.manual"),
+					func(state *terraform.State) error {
+						acls, err := listACLSetACLs(tt, state)
+						if err != nil {
+							return err
+						}
+
+						if len(acls) < 5 {
+							return fmt.Errorf("expected the 16 entries to be split in at least 4 ACLs and the default deny, got %d ACLs", len(acls))
+						}
+
+						for _, acl := range acls {
+							initialACLs[acl.ID] = acl
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				// Inserting an entry in the middle only changes the ACL it lands in, the other ones are kept as they are
+				Config: testAccACLSetConfig(updatedSubnets),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_lb_acl_set.allowlist", "rule.0.ip_subnet.#", "17"),
+					resource.TestCheckResourceAttr("scaleway_lb_acl_set.allowlist", "rule.0.ip_subnet.8", "192.168.0.0/16"),
+					isACLPresent(tt, "scaleway_lb_acl.manual"),
+					isACLSetMinimallyUpdated(tt, initialACLs),
+				),
+			},
+			{
+				ResourceName:            "scaleway_lb_acl_set.allowlist",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"chunk_size"},
+			},
+		},
+	})
+}
+
+// listACLSetACLs returns the ACLs of the frontend tracked in the acl_ids of the set
+func listACLSetACLs(tt *acctest.TestTools, state *terraform.State) ([]*lbSDK.ACL, error) {
+	rs := state.RootModule().Resources["scaleway_lb_acl_set.allowlist"]
+
+	lbAPI, zone, frontendID, err := lb.NewAPIWithZoneAndID(tt.Meta, rs.Primary.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := lbAPI.ListACLs(&lbSDK.ZonedAPIListACLsRequest{
+		Zone:       zone,
+		FrontendID: frontendID,
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, err
+	}
+
+	count, _ := strconv.Atoi(rs.Primary.Attributes["acl_ids.#"])
+	aclIDs := make([]string, 0, count)
+
+	for i := range count {
+		aclIDs = append(aclIDs, rs.Primary.Attributes["acl_ids."+strconv.Itoa(i)])
+	}
+
+	acls := []*lbSDK.ACL(nil)
+
+	for _, acl := range res.ACLs {
+		if slices.Contains(aclIDs, acl.ID) {
+			acls = append(acls, acl)
+		}
+	}
+
+	if len(acls) != len(aclIDs) {
+		return nil, fmt.Errorf("expected %d ACLs in the set, found %d on the frontend", len(aclIDs), len(acls))
+	}
+
+	return acls, nil
+}
+
+// isACLSetMinimallyUpdated checks that no ACL of the set was recreated and that a single ACL was added or updated
+func isACLSetMinimallyUpdated(tt *acctest.TestTools, initialACLs map[string]*lbSDK.ACL) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		acls, err := listACLSetACLs(tt, state)
+		if err != nil {
+			return err
+		}
+
+		changed := 0
+		kept := 0
+
+		for _, acl := range acls {
+			initialACL, found := initialACLs[acl.ID]
+			if !found {
+				changed++
+
+				continue
+			}
+
+			kept++
+
+			if !acl.UpdatedAt.Equal(*initialACL.UpdatedAt) {
+				changed++
+			}
+		}
+
+		if kept != len(initialACLs) {
+			return fmt.Errorf("%d ACLs of the set were recreated", len(initialACLs)-kept)
+		}
+
+		if changed != 1 {
+			return fmt.Errorf("expected a single ACL to be added or updated, got %d", changed)
+		}
+
+		return nil
+	}
+}
@@ -39,6 +39,7 @@ func ResourceBackend() *schema.Resource {
 		StateUpgraders: []schema.StateUpgrader{
 			{Version: 0, Type: lbUpgradeV1SchemaType(), Upgrade: UpgradeStateV1Func},
 		},
+		CustomizeDiff: customizeDiffLbBackendServerSelector,
 		Schema: map[string]*schema.Schema{
 			"lb_id": {
 				Type:        schema.TypeString,
@@ -91,6 +92,54 @@ func ResourceBackend() *schema.Resource {
 				Optional:    true,
 				Description: "Backend server IP addresses list (IPv4 or IPv6)",
 			},
+			"server_selector": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"server_ips"},
+				Description:   "Select the backend servers dynamically, their IPs are resolved when planning",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_tags": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Select the instances having all these tags",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"private_network_id": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+							DiffSuppressFunc: dsf.Locality,
+							Description:      "Select the IPs of this private network",
+						},
+						"ipam_tags": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Select the IPAM IPs having all these tags",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"is_ipv6": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Select IPv6 addresses instead of IPv4 addresses",
+						},
+					},
+				},
+			},
+			"selected_server_ips": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The backend server IPs resolved from the server_selector",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"send_proxy_v2": {
 				Type:        schema.TypeBool,
 				Description: "Enables PROXY protocol version 2",
@@ -372,6 +421,11 @@ func resourceLbBackendCreate(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 
+	serverIPs, err := expandLbBackendServerIPs(ctx, d, m, zone)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	createReq := &lbSDK.ZonedAPICreateBackendRequest{
 		Zone:                     zone,
 		LBID:                     lbID,
@@ -391,7 +445,7 @@ func resourceLbBackendCreate(ctx context.Context, d *schema.ResourceData, m inte
 			HTTPSConfig:     expandLbHCHTTPS(d.Get("health_check_https")),
 			CheckSendProxy:  d.Get("health_check_send_proxy").(bool),
 		},
-		ServerIP:              types.ExpandStrings(d.Get("server_ips")),
+		ServerIP:              serverIPs,
 		ProxyProtocol:         expandLbProxyProtocol(d.Get("proxy_protocol")),
 		TimeoutServer:         timeoutServer,
 		TimeoutConnect:        timeoutConnect,
@@ -483,7 +537,13 @@ func resourceLbBackendRead(ctx context.Context, d *schema.ResourceData, m interf
 	_ = d.Set("forward_port_algorithm", flattenLbForwardPortAlgorithm(backend.ForwardPortAlgorithm))
 	_ = d.Set("sticky_sessions", flattenLbStickySessionsType(backend.StickySessions))
 	_ = d.Set("sticky_sessions_cookie_name", backend.StickySessionsCookieName)
-	_ = d.Set("server_ips", backend.Pool)
+	if _, selectorExists := d.GetOk("server_selector"); selectorExists {
+		_ = d.Set("selected_server_ips", SortIPs(normalizeIPSubnetList(backend.Pool)))
+	} else {
+		_ = d.Set("server_ips", backend.Pool)
+		_ = d.Set("selected_server_ips", nil)
+	}
+
 	_ = d.Set("proxy_protocol", flattenLbProxyProtocol(backend.ProxyProtocol))
 	_ = d.Set("timeout_server", types.FlattenDuration(backend.TimeoutServer))
 	_ = d.Set("timeout_connect", types.FlattenDuration(backend.TimeoutConnect))
@@ -647,10 +707,15 @@ func resourceLbBackendUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	}
 
 	// Update Backend servers
+	serverIPs, err := expandLbBackendServerIPs(ctx, d, m, zone)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_, err = lbAPI.SetBackendServers(&lbSDK.ZonedAPISetBackendServersRequest{
 		Zone:      zone,
 		BackendID: ID,
-		ServerIP:  types.ExpandStrings(d.Get("server_ips")),
+		ServerIP:  serverIPs,
 	}, scw.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
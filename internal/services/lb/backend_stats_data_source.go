@@ -0,0 +1,79 @@
+package lb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func DataSourceBackendStats() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceLbBackendStatsRead,
+		Schema: map[string]*schema.Schema{
+			"backend_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				Description:      "The ID of the backend",
+			},
+			"lb_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the load-balancer of the backend",
+			},
+			"backend_servers": backendServerStatsSchema(),
+			"all_healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the last health check of every server of the backend passed, false when there is no server",
+			},
+			"server_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of backend servers",
+			},
+			"zone": zonal.Schema(),
+		},
+	}
+}
+
+func DataSourceLbBackendStatsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	lbAPI, zone, err := lbAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backendID := locality.ExpandID(d.Get("backend_id"))
+
+	backend, err := lbAPI.GetBackend(&lbSDK.ZonedAPIGetBackendRequest{
+		Zone:      zone,
+		BackendID: backendID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := lbAPI.ListBackendStats(&lbSDK.ZonedAPIListBackendStatsRequest{
+		Zone:      zone,
+		LBID:      backend.LB.ID,
+		BackendID: &backend.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(zonal.NewIDString(zone, backend.ID))
+	_ = d.Set("lb_id", zonal.NewIDString(zone, backend.LB.ID))
+	_ = d.Set("backend_servers", flattenLbBackendServerStats(zone, res.BackendServersStats))
+	_ = d.Set("all_healthy", AreBackendServersHealthy(res.BackendServersStats))
+	_ = d.Set("server_count", len(res.BackendServersStats))
+	_ = d.Set("zone", zone)
+
+	return nil
+}
@@ -484,6 +484,112 @@ func TestAccBackend_HealthCheck_Port(t *testing.T) {
 	})
 }
 
+func TestAccBackend_ServerSelector(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      isBackendDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				// The private network ID is unknown when planning, the selector is resolved once the instances exist
+				Config: testAccBackendServerSelectorConfig(2, true),
+				Check: resource.ComposeTestCheckFunc(
+					isBackendPresent(tt, "scaleway_lb_backend.main"),
+					resource.TestCheckResourceAttr("scaleway_lb_backend.main", "server_ips.#", "0"),
+					resource.TestCheckResourceAttr("scaleway_lb_backend.main", "selected_server_ips.#", "2"),
+				),
+			},
+			{
+				// An instance created in the same apply is not known when planning, the next plan selects it
+				Config:             testAccBackendServerSelectorConfig(3, true),
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_lb_backend.main", "selected_server_ips.#", "2"),
+				),
+			},
+			{
+				Config: testAccBackendServerSelectorConfig(3, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_lb_backend.main", "selected_server_ips.#", "3"),
+				),
+			},
+			{
+				// The selection does not change while the instances stay the same
+				Config:   testAccBackendServerSelectorConfig(3, true),
+				PlanOnly: true,
+			},
+			{
+				// Selecting by private network only does not select the IP of the Load Balancer on this network
+				Config: testAccBackendServerSelectorConfig(3, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_lb_backend.main", "selected_server_ips.#", "3"),
+				),
+			},
+			{
+				// The selector cannot be known on import, the selected IPs are imported as server_ips
+				ResourceName:            "scaleway_lb_backend.main",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"server_selector", "server_ips", "selected_server_ips"},
+			},
+		},
+	})
+}
+
+func testAccBackendServerSelectorConfig(instanceCount int, withInstanceTags bool) string {
+	instanceTags := ""
+	if withInstanceTags {
+		instanceTags = `instance_tags      = ["test-lb-backend-server-selector"]`
+	}
+
+	return fmt.Sprintf(`
+		resource scaleway_vpc_private_network main {
+			name = "test-lb-backend-server-selector"
+		}
+
+		resource scaleway_instance_server main {
+			count = %d
+			name  = "test-lb-backend-server-selector-${count.index}"
+			type  = "DEV1-S"
+			image = "ubuntu_jammy"
+			tags  = ["test-lb-backend-server-selector"]
+
+			private_network {
+				pn_id = scaleway_vpc_private_network.main.id
+			}
+		}
+
+		resource scaleway_lb_ip main {}
+
+		resource scaleway_lb main {
+			ip_id = scaleway_lb_ip.main.id
+			name  = "test-lb-backend-server-selector"
+			type  = "LB-S"
+
+			private_network {
+				private_network_id = scaleway_vpc_private_network.main.id
+			}
+		}
+
+		resource scaleway_lb_backend main {
+			lb_id            = scaleway_lb.main.id
+			name             = "backend"
+			forward_protocol = "tcp"
+			forward_port     = 80
+
+			server_selector {
+				%s
+				private_network_id = scaleway_vpc_private_network.main.id
+			}
+
+			depends_on = [scaleway_instance_server.main]
+		}
+	`, instanceCount, instanceTags)
+}
+
 func isBackendPresent(tt *acctest.TestTools, n string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[n]
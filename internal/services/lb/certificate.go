@@ -4,14 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
 )
 
 func ResourceCertificate() *schema.Resource {
@@ -31,6 +35,10 @@ func ResourceCertificate() *schema.Resource {
 		StateUpgraders: []schema.StateUpgrader{
 			{Version: 0, Type: lbUpgradeV1SchemaType(), Upgrade: UpgradeStateV1Func},
 		},
+		CustomizeDiff: customizeDiffLbCertificateSecretRevision,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			ValidateLbCertificateExpiry,
+		},
 		Schema: map[string]*schema.Schema{
 			"lb_id": {
 				Type:        schema.TypeString,
@@ -81,17 +89,45 @@ func ResourceCertificate() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"certificate_chain": {
-							Type:        schema.TypeString,
-							Required:    true,
-							ForceNew:    true,
-							Sensitive:   true,
-							Description: "The full PEM-formatted certificate chain",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Sensitive:    true,
+							Description:  "The full PEM-formatted certificate chain",
+							ExactlyOneOf: []string{"custom_certificate.0.certificate_chain", "custom_certificate.0.secret_id"},
+						},
+						"secret_id": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							Description:      "The ID of the Secret Manager secret holding the full PEM-formatted certificate chain",
+							ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+							DiffSuppressFunc: dsf.Locality,
+						},
+						"secret_version": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Description:  "The revision of the secret to use, either a number, latest or latest_enabled. Defaults to latest_enabled",
+							RequiredWith: []string{"custom_certificate.0.secret_id"},
 						},
 					},
 				},
 			},
 
+			"expiry_warning_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Number of days before the certificate expiration from which a warning is raised",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
 			// Readonly attributes
+			"secret_revision": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The revision of the secret used to create the certificate",
+			},
 			"common_name": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -152,6 +188,16 @@ func resourceLbCertificateCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(errors.New("you need to define either letsencrypt or custom_certificate configuration"))
 	}
 
+	if createReq.CustomCertificate != nil && createReq.CustomCertificate.CertificateChain == "" {
+		secretVersion, err := accessLbCertificateSecret(ctx, d, m, zone)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		createReq.CustomCertificate.CertificateChain = string(secretVersion.Data)
+		_ = d.Set("secret_revision", int(secretVersion.Revision))
+	}
+
 	_, err = waitForLB(ctx, lbAPI, zone, lbID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		if httperrors.Is403(err) {
@@ -228,6 +274,16 @@ func resourceLbCertificateRead(ctx context.Context, d *schema.ResourceData, m in
 		})
 	}
 
+	if warningDays := d.Get("expiry_warning_days").(int); warningDays > 0 && certificate.NotValidAfter != nil {
+		if remaining := time.Until(*certificate.NotValidAfter); remaining < time.Duration(warningDays)*24*time.Hour {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("certificate %s expires in less than %d days", certificate.ID, warningDays),
+				Detail:   "The certificate is not valid after " + certificate.NotValidAfter.Format(time.RFC3339),
+			})
+		}
+	}
+
 	return diags
 }
 
@@ -3,20 +3,30 @@ package lb
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	ipamSDK "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
 	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	secretSDK "github.com/scaleway/scaleway-sdk-go/api/secret/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	validator "github.com/scaleway/scaleway-sdk-go/validation"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -103,6 +113,116 @@ func PrivateNetworksCompare(oldPNs, newPNs []*lbSDK.PrivateNetwork) ([]*lbSDK.Pr
 	return toDetach, toAttach
 }
 
+// PrivateNetworksMigration orders the changes of private networks to attach the new ones before detaching the old ones.
+// A private network whose configuration changed can only be detached then attached again, it is returned in toReattach
+func PrivateNetworksMigration(oldPNs, newPNs []*lbSDK.PrivateNetwork) (toAttach, toDetach, toReattach []*lbSDK.PrivateNetwork) {
+	detached, attached := PrivateNetworksCompare(oldPNs, newPNs)
+
+	detachedIDs := make(map[string]struct{}, len(detached))
+	for _, pn := range detached {
+		detachedIDs[pn.PrivateNetworkID] = struct{}{}
+	}
+
+	reattachedIDs := make(map[string]struct{}, len(attached))
+
+	for _, pn := range attached {
+		if _, found := detachedIDs[pn.PrivateNetworkID]; found {
+			reattachedIDs[pn.PrivateNetworkID] = struct{}{}
+			toReattach = append(toReattach, pn)
+		} else {
+			toAttach = append(toAttach, pn)
+		}
+	}
+
+	for _, pn := range detached {
+		if _, found := reattachedIDs[pn.PrivateNetworkID]; !found {
+			toDetach = append(toDetach, pn)
+		}
+	}
+
+	return toAttach, toDetach, toReattach
+}
+
+// BackendServersOnPrivateNetworks returns the IPs of the servers reachable through the given private networks,
+// pnIPs holds the addresses of the resources attached to these private networks
+func BackendServersOnPrivateNetworks(serverIPs []string, pnIPs map[string]struct{}) []string {
+	ips := []string(nil)
+
+	for _, ip := range serverIPs {
+		if _, found := pnIPs[normalizeIPSubnet(ip)]; found {
+			ips = append(ips, ip)
+		}
+	}
+
+	return ips
+}
+
+// listLbPrivateNetworksIPs lists the IPv4 of the resources attached to the private networks
+func listLbPrivateNetworksIPs(ctx context.Context, ipamAPI *ipamSDK.API, region scw.Region, pns []*lbSDK.PrivateNetwork) (map[string]struct{}, error) {
+	ips := make(map[string]struct{})
+
+	for _, pn := range pns {
+		res, err := ipamAPI.ListIPs(&ipamSDK.ListIPsRequest{
+			Region:           region,
+			PrivateNetworkID: &pn.PrivateNetworkID,
+			Attached:         scw.BoolPtr(true),
+			IsIPv6:           scw.BoolPtr(false),
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the IPs of private network %s: %w", pn.PrivateNetworkID, err)
+		}
+
+		for _, ip := range res.IPs {
+			ips[ip.Address.IP.String()] = struct{}{}
+		}
+	}
+
+	return ips, nil
+}
+
+// checkLbBackendServersDetachable fails when a backend of the load-balancer still targets servers through a private network
+// about to be detached. Backends are managed by their own resources, they are updated after the load-balancer,
+// so their server_ips have to be moved to another private network before this one is detached
+func checkLbBackendServersDetachable(ctx context.Context, lbAPI *lbSDK.ZonedAPI, m interface{}, zone scw.Zone, lbID string, detached []*lbSDK.PrivateNetwork) error {
+	if len(detached) == 0 {
+		return nil
+	}
+
+	region, err := zone.Region()
+	if err != nil {
+		return err
+	}
+
+	detachedIPs, err := listLbPrivateNetworksIPs(ctx, ipamSDK.NewAPI(meta.ExtractScwClient(m)), region, detached)
+	if err != nil {
+		return err
+	}
+
+	backends, err := lbAPI.ListBackends(&lbSDK.ZonedAPIListBackendsRequest{
+		Zone: zone,
+		LBID: lbID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	detachedIDs := make([]string, 0, len(detached))
+	for _, pn := range detached {
+		detachedIDs = append(detachedIDs, pn.PrivateNetworkID)
+	}
+
+	for _, backend := range backends.Backends {
+		if ips := BackendServersOnPrivateNetworks(backend.Pool, detachedIPs); len(ips) > 0 {
+			return fmt.Errorf("backend %s (%s) still targets servers %s through the private networks being detached (%s): "+
+				"first apply with both private networks attached and the server_ips of the scaleway_lb_backend set to the addresses "+
+				"of the servers on the new private network, then remove the old private network",
+				backend.Name, zonal.NewIDString(zone, backend.ID), strings.Join(ips, ", "), strings.Join(detachedIDs, ", "))
+		}
+	}
+
+	return nil
+}
+
 func lbUpgradeV1SchemaType() cty.Type {
 	return cty.Object(map[string]cty.Type{
 		"id": cty.String,
@@ -226,3 +346,364 @@ func customizeDiffAssignFlexibleIPv6(_ context.Context, diff *schema.ResourceDif
 
 	return nil
 }
+
+// AreBackendServersHealthy reports whether the last health check of every server passed,
+// a conditional pass counts as healthy. Without any server nothing is healthy
+func AreBackendServersHealthy(stats []*lbSDK.BackendServerStats) bool {
+	if len(stats) == 0 {
+		return false
+	}
+
+	for _, stat := range stats {
+		switch stat.LastHealthCheckStatus {
+		case lbSDK.BackendServerStatsHealthCheckStatusPassed, lbSDK.BackendServerStatsHealthCheckStatusCondpass:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// expandLbBackendServerIPs returns the IPs of the backend servers, either the static list or the IPs of the server_selector.
+// The selected IPs planned are used, they are only resolved again when they were unknown when planning
+func expandLbBackendServerIPs(ctx context.Context, d *schema.ResourceData, m interface{}, zone scw.Zone) ([]string, error) {
+	rawSelector, selectorExists := d.GetOk("server_selector")
+	if !selectorExists {
+		return types.ExpandStrings(d.Get("server_ips")), nil
+	}
+
+	if rawPlan := d.GetRawPlan(); !rawPlan.IsNull() && rawPlan.GetAttr("selected_server_ips").IsKnown() {
+		return types.ExpandStrings(d.Get("selected_server_ips")), nil
+	}
+
+	return resolveLbBackendServerSelector(ctx, m, zone, rawSelector)
+}
+
+// customizeDiffLbBackendServerSelector resolves the server_selector to plan the backend servers
+func customizeDiffLbBackendServerSelector(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	rawSelector, selectorExists := diff.GetOk("server_selector")
+	if !selectorExists {
+		if len(diff.Get("selected_server_ips").([]interface{})) > 0 {
+			return diff.SetNew("selected_server_ips", []string(nil))
+		}
+
+		return nil
+	}
+
+	if !diff.NewValueKnown("server_selector") {
+		return diff.SetNewComputed("selected_server_ips")
+	}
+
+	zone, err := meta.ExtractZone(diff, m)
+	if err != nil {
+		return err
+	}
+
+	if lbID, ok := diff.Get("lb_id").(string); ok && lbID != "" {
+		if lbZone, _, err := zonal.ParseID(lbID); err == nil {
+			zone = lbZone
+		}
+	}
+
+	selectedIPs, err := resolveLbBackendServerSelector(ctx, m, zone, rawSelector)
+	if err != nil {
+		return err
+	}
+
+	oldIPs := types.ExpandStrings(diff.Get("selected_server_ips"))
+	if types.CompareStringListsIgnoringOrder(normalizeIPSubnetList(oldIPs), selectedIPs) {
+		return nil
+	}
+
+	return diff.SetNew("selected_server_ips", selectedIPs)
+}
+
+// resolveLbBackendServerSelector lists the IPs matching the selector, sorted to avoid changes in the plan
+func resolveLbBackendServerSelector(ctx context.Context, m interface{}, zone scw.Zone, rawSelector interface{}) ([]string, error) {
+	rawList := rawSelector.([]interface{})
+	if len(rawList) == 0 || rawList[0] == nil {
+		return nil, nil
+	}
+
+	selector := rawList[0].(map[string]interface{})
+	instanceTags := types.ExpandStrings(selector["instance_tags"])
+	privateNetworkID := locality.ExpandID(selector["private_network_id"])
+	ipamTags := types.ExpandStrings(selector["ipam_tags"])
+	isIPv6 := selector["is_ipv6"].(bool)
+
+	if len(instanceTags) == 0 && privateNetworkID == "" && len(ipamTags) == 0 {
+		return nil, errors.New("server_selector must define at least one of instance_tags, private_network_id or ipam_tags")
+	}
+
+	region, err := zone.Region()
+	if err != nil {
+		return nil, err
+	}
+
+	client := meta.ExtractScwClient(m)
+	// only the IPs of Instances are listed, not the ones of the Load Balancer or of other products
+	listIPsReq := &ipamSDK.ListIPsRequest{
+		Region:       region,
+		Attached:     scw.BoolPtr(true),
+		IsIPv6:       &isIPv6,
+		Tags:         ipamTags,
+		ResourceType: ipamSDK.ResourceTypeInstanceServer,
+	}
+
+	if privateNetworkID != "" {
+		listIPsReq.PrivateNetworkID = &privateNetworkID
+	}
+
+	if len(instanceTags) > 0 {
+		servers, err := instanceSDK.NewAPI(client).ListServers(&instanceSDK.ListServersRequest{
+			Zone: zone,
+			Tags: instanceTags,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list instances with tags %v: %w", instanceTags, err)
+		}
+
+		// The IPAM API would list every IP without a resource filter
+		if len(servers.Servers) == 0 {
+			return nil, nil
+		}
+
+		for _, server := range servers.Servers {
+			listIPsReq.ResourceIDs = append(listIPsReq.ResourceIDs, server.ID)
+		}
+	}
+
+	res, err := ipamSDK.NewAPI(client).ListIPs(listIPsReq, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the IPs of the server_selector: %w", err)
+	}
+
+	ips := make([]string, 0, len(res.IPs))
+	for _, ip := range res.IPs {
+		ips = append(ips, ip.Address.IP.String())
+	}
+
+	return SortIPs(normalizeIPSubnetList(ips)), nil
+}
+
+// SortIPs sorts IP addresses by their numeric value, without duplicates
+func SortIPs(ips []string) []string {
+	sorted := make([]string, 0, len(ips))
+	seen := make(map[string]struct{}, len(ips))
+
+	for _, ip := range ips {
+		if _, ok := seen[ip]; ok {
+			continue
+		}
+
+		seen[ip] = struct{}{}
+		sorted = append(sorted, ip)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := net.ParseIP(sorted[i]), net.ParseIP(sorted[j])
+		if a == nil || b == nil {
+			return sorted[i] < sorted[j]
+		}
+
+		return bytes.Compare(a.To16(), b.To16()) < 0
+	})
+
+	return sorted
+}
+
+// lbCertificateSecret returns the secret of a custom certificate and the revision to use.
+// The region of the secret defaults to the region of the load-balancer
+func lbCertificateSecret(rawCustomCertificate interface{}, zone scw.Zone) (regional.ID, string, error) {
+	rawList, ok := rawCustomCertificate.([]interface{})
+	if !ok || len(rawList) == 0 || rawList[0] == nil {
+		return regional.ID{}, "", nil
+	}
+
+	rawMap := rawList[0].(map[string]interface{})
+
+	secretID, _ := rawMap["secret_id"].(string)
+	if secretID == "" {
+		return regional.ID{}, "", nil
+	}
+
+	secret := regional.ExpandID(secretID)
+	if secret.Region == "" {
+		region, err := zone.Region()
+		if err != nil {
+			return regional.ID{}, "", err
+		}
+
+		secret.Region = region
+	}
+
+	revision, _ := rawMap["secret_version"].(string)
+	if revision == "" {
+		revision = "latest_enabled"
+	}
+
+	return secret, revision, nil
+}
+
+// accessLbCertificateSecret fetches the certificate chain stored in Secret Manager, using the planned revision when known
+func accessLbCertificateSecret(ctx context.Context, d *schema.ResourceData, m interface{}, zone scw.Zone) (*secretSDK.AccessSecretVersionResponse, error) {
+	secret, revision, err := lbCertificateSecret(d.Get("custom_certificate"), zone)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret.ID == "" {
+		return nil, errors.New("custom_certificate requires either certificate_chain or secret_id")
+	}
+
+	if plannedRevision := d.Get("secret_revision").(int); plannedRevision > 0 {
+		revision = strconv.Itoa(plannedRevision)
+	}
+
+	res, err := secretSDK.NewAPI(meta.ExtractScwClient(m)).AccessSecretVersion(&secretSDK.AccessSecretVersionRequest{
+		Region:   secret.Region,
+		SecretID: secret.ID,
+		Revision: revision,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to access revision %s of secret %s: %w", revision, secret.ID, err)
+	}
+
+	return res, nil
+}
+
+// customizeDiffLbCertificateSecretRevision resolves the secret revision of a custom certificate,
+// a new revision of the secret replaces the certificate
+func customizeDiffLbCertificateSecretRevision(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.NewValueKnown("custom_certificate") || !diff.NewValueKnown("lb_id") {
+		return diff.SetNewComputed("secret_revision")
+	}
+
+	zone, _, err := zonal.ParseID(diff.Get("lb_id").(string))
+	if err != nil {
+		return err
+	}
+
+	secret, revision, err := lbCertificateSecret(diff.Get("custom_certificate"), zone)
+	if err != nil {
+		return err
+	}
+
+	if secret.ID == "" {
+		return nil
+	}
+
+	secretVersion, err := secretSDK.NewAPI(meta.ExtractScwClient(m)).GetSecretVersion(&secretSDK.GetSecretVersionRequest{
+		Region:   secret.Region,
+		SecretID: secret.ID,
+		Revision: revision,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		// The secret version may be created in the same apply
+		if httperrors.Is404(err) && diff.Id() == "" {
+			return diff.SetNewComputed("secret_revision")
+		}
+
+		return fmt.Errorf("failed to get revision %s of secret %s: %w", revision, secret.ID, err)
+	}
+
+	if diff.Get("secret_revision").(int) == int(secretVersion.Revision) {
+		return nil
+	}
+
+	err = diff.SetNew("secret_revision", int(secretVersion.Revision))
+	if err != nil {
+		return err
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	return diff.ForceNew("secret_revision")
+}
+
+// ValidateLbCertificateExpiry warns when planning a custom certificate chain expiring within expiry_warning_days.
+// Chains sourced from a secret are only known at apply time, their expiration is checked when reading the certificate
+func ValidateLbCertificateExpiry(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	rawWarningDays := getKnownAttr(req.RawConfig, "expiry_warning_days")
+	if rawWarningDays.IsNull() || !rawWarningDays.Type().Equals(cty.Number) {
+		return
+	}
+
+	warningDays, _ := rawWarningDays.AsBigFloat().Int64()
+	if warningDays <= 0 {
+		return
+	}
+
+	rawCustomCertificate := getKnownAttr(req.RawConfig, "custom_certificate")
+	if rawCustomCertificate.IsNull() || !rawCustomCertificate.CanIterateElements() || rawCustomCertificate.LengthInt() == 0 {
+		return
+	}
+
+	rawChain := getKnownAttr(rawCustomCertificate.Index(cty.NumberIntVal(0)), "certificate_chain")
+	if rawChain.IsNull() || !rawChain.Type().Equals(cty.String) {
+		return
+	}
+
+	notValidAfter, err := lbCertificateNotValidAfter(rawChain.AsString())
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "cannot check the expiration of the certificate",
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath("custom_certificate").IndexInt(0).GetAttr("certificate_chain"),
+		})
+
+		return
+	}
+
+	if time.Until(notValidAfter) < time.Duration(warningDays)*24*time.Hour {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("certificate expires in less than %d days", warningDays),
+			Detail:        "The certificate is not valid after " + notValidAfter.Format(time.RFC3339),
+			AttributePath: cty.GetAttrPath("custom_certificate").IndexInt(0).GetAttr("certificate_chain"),
+		})
+	}
+}
+
+// getKnownAttr returns the attribute of an object, or a null value when it is missing or unknown
+func getKnownAttr(value cty.Value, name string) cty.Value {
+	if value.IsNull() || !value.IsKnown() || !value.Type().IsObjectType() || !value.Type().HasAttribute(name) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	attr := value.GetAttr(name)
+	if !attr.IsKnown() {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	return attr
+}
+
+// lbCertificateNotValidAfter returns the expiration date of the first certificate of a PEM chain
+func lbCertificateNotValidAfter(chain string) (time.Time, error) {
+	rest := []byte(chain)
+
+	for {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return time.Time{}, errors.New("no certificate found in the PEM chain")
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse the certificate: %w", err)
+		}
+
+		return certificate.NotAfter, nil
+	}
+}
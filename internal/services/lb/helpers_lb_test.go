@@ -1,12 +1,25 @@
 package lb_test
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/lb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsEqualPrivateNetwork(t *testing.T) {
@@ -159,3 +172,233 @@ func TestPrivateNetworksCompare(t *testing.T) {
 		})
 	}
 }
+
+func TestPrivateNetworksMigration(t *testing.T) {
+	tests := []struct {
+		name               string
+		oldPNs             []*lbSDK.PrivateNetwork
+		newPNs             []*lbSDK.PrivateNetwork
+		expectedToAttach   []*lbSDK.PrivateNetwork
+		expectedToDetach   []*lbSDK.PrivateNetwork
+		expectedToReattach []*lbSDK.PrivateNetwork
+	}{
+		{
+			name: "private network switched",
+			oldPNs: []*lbSDK.PrivateNetwork{
+				{PrivateNetworkID: "pn1", DHCPConfig: &lbSDK.PrivateNetworkDHCPConfig{}},
+			},
+			newPNs: []*lbSDK.PrivateNetwork{
+				{PrivateNetworkID: "pn2", IpamIDs: []string{"ipam1"}},
+			},
+			expectedToAttach: []*lbSDK.PrivateNetwork{
+				{PrivateNetworkID: "pn2", IpamIDs: []string{"ipam1"}},
+			},
+			expectedToDetach: []*lbSDK.PrivateNetwork{
+				{PrivateNetworkID: "pn1", DHCPConfig: &lbSDK.PrivateNetworkDHCPConfig{}},
+			},
+		},
+		{
+			name: "private network configuration changed from DHCP to static",
+			oldPNs: []*lbSDK.PrivateNetwork{
+				{PrivateNetworkID: "pn1", DHCPConfig: &lbSDK.PrivateNetworkDHCPConfig{}},
+			},
+			newPNs: []*lbSDK.PrivateNetwork{
+				{PrivateNetworkID: "pn1", StaticConfig: &lbSDK.PrivateNetworkStaticConfig{IPAddress: scw.StringsPtr([]string{"192.168.1.1"})}},
+			},
+			expectedToReattach: []*lbSDK.PrivateNetwork{
+				{PrivateNetworkID: "pn1", StaticConfig: &lbSDK.PrivateNetworkStaticConfig{IPAddress: scw.StringsPtr([]string{"192.168.1.1"})}},
+			},
+		},
+		{
+			name: "private network added and another one changed",
+			oldPNs: []*lbSDK.PrivateNetwork{
+				{PrivateNetworkID: "pn1", DHCPConfig: &lbSDK.PrivateNetworkDHCPConfig{}},
+			},
+			newPNs: []*lbSDK.PrivateNetwork{
+				{PrivateNetworkID: "pn1", IpamIDs: []string{"ipam1"}},
+				{PrivateNetworkID: "pn2", DHCPConfig: &lbSDK.PrivateNetworkDHCPConfig{}},
+			},
+			expectedToAttach: []*lbSDK.PrivateNetwork{
+				{PrivateNetworkID: "pn2", DHCPConfig: &lbSDK.PrivateNetworkDHCPConfig{}},
+			},
+			expectedToReattach: []*lbSDK.PrivateNetwork{
+				{PrivateNetworkID: "pn1", IpamIDs: []string{"ipam1"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toAttach, toDetach, toReattach := lb.PrivateNetworksMigration(tt.oldPNs, tt.newPNs)
+			assert.ElementsMatch(t, tt.expectedToAttach, toAttach)
+			assert.ElementsMatch(t, tt.expectedToDetach, toDetach)
+			assert.ElementsMatch(t, tt.expectedToReattach, toReattach)
+		})
+	}
+}
+
+func TestBackendServersOnPrivateNetworks(t *testing.T) {
+	pnIPs := map[string]struct{}{
+		"10.0.0.2": {},
+		"10.0.0.3": {},
+	}
+
+	tests := []struct {
+		name        string
+		serverIPs   []string
+		expectedIPs []string
+	}{
+		{
+			name:        "noServer",
+			serverIPs:   nil,
+			expectedIPs: nil,
+		},
+		{
+			name:        "notOnPrivateNetworks",
+			serverIPs:   []string{"192.168.0.1"},
+			expectedIPs: nil,
+		},
+		{
+			name:        "onPrivateNetworks",
+			serverIPs:   []string{"10.0.0.2", "192.168.0.1", "10.0.0.3/32"},
+			expectedIPs: []string{"10.0.0.2", "10.0.0.3/32"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedIPs, lb.BackendServersOnPrivateNetworks(tt.serverIPs, pnIPs))
+		})
+	}
+}
+
+func TestAreBackendServersHealthy(t *testing.T) {
+	tests := []struct {
+		name     string
+		stats    []*lbSDK.BackendServerStats
+		expected bool
+	}{
+		{
+			name:     "noServer",
+			stats:    nil,
+			expected: false,
+		},
+		{
+			name: "allPassed",
+			stats: []*lbSDK.BackendServerStats{
+				{IP: "10.0.0.1", LastHealthCheckStatus: lbSDK.BackendServerStatsHealthCheckStatusPassed},
+				{IP: "10.0.0.2", LastHealthCheckStatus: lbSDK.BackendServerStatsHealthCheckStatusCondpass},
+			},
+			expected: true,
+		},
+		{
+			name: "oneFailed",
+			stats: []*lbSDK.BackendServerStats{
+				{IP: "10.0.0.1", LastHealthCheckStatus: lbSDK.BackendServerStatsHealthCheckStatusPassed},
+				{IP: "10.0.0.2", LastHealthCheckStatus: lbSDK.BackendServerStatsHealthCheckStatusFailed},
+			},
+			expected: false,
+		},
+		{
+			name: "unknown",
+			stats: []*lbSDK.BackendServerStats{
+				{IP: "10.0.0.1", LastHealthCheckStatus: lbSDK.BackendServerStatsHealthCheckStatusUnknown},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, lb.AreBackendServersHealthy(tt.stats))
+		})
+	}
+}
+
+func TestSortIPs(t *testing.T) {
+	assert.Equal(t,
+		[]string{"10.0.0.2", "10.0.0.10", "192.168.1.1", "fd00::1"},
+		lb.SortIPs([]string{"fd00::1", "10.0.0.10", "192.168.1.1", "10.0.0.2", "10.0.0.10"}),
+	)
+	assert.Empty(t, lb.SortIPs(nil))
+}
+
+func testCertificateChain(t *testing.T, notAfter time.Time) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func testCertificateRawConfig(chain cty.Value, warningDays cty.Value) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"expiry_warning_days": warningDays,
+		"custom_certificate": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"certificate_chain": chain,
+			}),
+		}),
+	})
+}
+
+func TestValidateLbCertificateExpiry(t *testing.T) {
+	expiringChain := cty.StringVal(testCertificateChain(t, time.Now().Add(10*24*time.Hour)))
+
+	tests := []struct {
+		name      string
+		rawConfig cty.Value
+		warning   string
+	}{
+		{
+			name:      "expires within the warning days",
+			rawConfig: testCertificateRawConfig(expiringChain, cty.NumberIntVal(30)),
+			warning:   "certificate expires in less than 30 days",
+		},
+		{
+			name:      "expires after the warning days",
+			rawConfig: testCertificateRawConfig(expiringChain, cty.NumberIntVal(5)),
+		},
+		{
+			name:      "warning disabled",
+			rawConfig: testCertificateRawConfig(expiringChain, cty.NullVal(cty.Number)),
+		},
+		{
+			name:      "chain unknown when planning",
+			rawConfig: testCertificateRawConfig(cty.UnknownVal(cty.String), cty.NumberIntVal(30)),
+		},
+		{
+			name:      "chain without certificate",
+			rawConfig: testCertificateRawConfig(cty.StringVal("not a certificate"), cty.NumberIntVal(30)),
+			warning:   "cannot check the expiration of the certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			lb.ValidateLbCertificateExpiry(context.Background(), schema.ValidateResourceConfigFuncRequest{RawConfig: tt.rawConfig}, resp)
+
+			if tt.warning == "" {
+				assert.Empty(t, resp.Diagnostics)
+
+				return
+			}
+
+			require.Len(t, resp.Diagnostics, 1)
+			assert.Equal(t, diag.Warning, resp.Diagnostics[0].Severity)
+			assert.Equal(t, tt.warning, resp.Diagnostics[0].Summary)
+		})
+	}
+}
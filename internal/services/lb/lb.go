@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -199,6 +200,13 @@ func ResourceLb() *schema.Resource {
 				DiffSuppressFunc: dsf.OrderDiff,
 				ConflictsWith:    []string{"assign_flexible_ip", "assign_flexible_ipv6"},
 			},
+			"subscriber_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "The ID of the subscriber notified when a backend server of the load-balancer goes down",
+			},
 			"region":          regional.ComputedSchema(),
 			"zone":            zonal.Schema(),
 			"organization_id": account.OrganizationIDSchema(),
@@ -264,6 +272,13 @@ func resourceLbCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 		}
 	}
 
+	if subscriberID, ok := d.GetOk("subscriber_id"); ok {
+		err = subscribeToLB(ctx, lbAPI, zone, lb.ID, locality.ExpandID(subscriberID), d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceLbRead(ctx, d, m)
 }
 
@@ -301,6 +316,12 @@ func resourceLbRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	_ = d.Set("type", strings.ToUpper(lb.Type))
 	_ = d.Set("ssl_compatibility_level", lb.SslCompatibilityLevel.String())
 
+	if lb.Subscriber != nil {
+		_ = d.Set("subscriber_id", zonal.NewIDString(zone, lb.Subscriber.ID))
+	} else {
+		_ = d.Set("subscriber_id", "")
+	}
+
 	if len(lb.IP) > 0 {
 		_ = d.Set("ip_id", zonal.NewIDString(zone, lb.IP[0].ID))
 		_ = d.Set("ip_ids", flattenLBIPIDs(zone, lb.IP))
@@ -344,6 +365,8 @@ func resourceLbUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 		return diag.FromErr(err)
 	}
 
+	var diags diag.Diagnostics
+
 	req := &lbSDK.ZonedAPIUpdateLBRequest{
 		Zone:                  zone,
 		LBID:                  ID,
@@ -471,6 +494,24 @@ func resourceLbUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 		}
 	}
 
+	if d.HasChange("subscriber_id") {
+		if subscriberID, ok := d.GetOk("subscriber_id"); ok {
+			err = subscribeToLB(ctx, lbAPI, zone, ID, locality.ExpandID(subscriberID), d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		} else {
+			// the subscriber may have been deleted along with the subscription
+			_, err = lbAPI.UnsubscribeFromLB(&lbSDK.ZonedAPIUnsubscribeFromLBRequest{
+				Zone: zone,
+				LBID: ID,
+			}, scw.WithContext(ctx))
+			if err != nil && !httperrors.Is404(err) {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	////
 	// Attach / Detach Private Networks
 	////
@@ -499,9 +540,37 @@ func resourceLbUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 			return diag.FromErr(err)
 		}
 
-		toDetach, toAttach := PrivateNetworksCompare(oldPNConfigs, newPNConfigs)
+		toAttach, toDetach, toReattach := PrivateNetworksMigration(oldPNConfigs, newPNConfigs)
 
-		// detach private networks
+		// nothing is changed when backend servers would become unreachable
+		err = checkLbBackendServersDetachable(ctx, lbAPI, m, zone, ID, toDetach)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// attach new private networks first so that the load-balancer is never left without connectivity
+		_, err = attachLBPrivateNetworks(ctx, lbAPI, zone, toAttach, ID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitForLB(ctx, lbAPI, zone, ID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil && !httperrors.Is404(err) {
+			return diag.FromErr(err)
+		}
+
+		for _, pn := range toReattach {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "Private network attached again",
+				Detail:        fmt.Sprintf("The configuration of private network %s changed, it cannot be updated in place: it is detached then attached again, which interrupts the connectivity on this private network. Switch the load-balancer to another private network to avoid it.", pn.PrivateNetworkID),
+				AttributePath: cty.GetAttrPath("private_network"),
+			})
+		}
+
+		// detach removed private networks and those whose configuration changed.
+		// There is no API to update an attachment, a private network whose configuration changed (e.g. from DHCP to ipam_ids)
+		// is detached then attached again, which interrupts the connectivity on this private network
 		for _, pn := range toDetach {
 			err = lbAPI.DetachPrivateNetwork(&lbSDK.ZonedAPIDetachPrivateNetworkRequest{
 				Zone:             zone,
@@ -513,6 +582,17 @@ func resourceLbUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 			}
 		}
 
+		for _, pn := range toReattach {
+			err = lbAPI.DetachPrivateNetwork(&lbSDK.ZonedAPIDetachPrivateNetworkRequest{
+				Zone:             zone,
+				LBID:             ID,
+				PrivateNetworkID: pn.PrivateNetworkID,
+			}, scw.WithContext(ctx))
+			if err != nil && !httperrors.Is404(err) {
+				return diag.FromErr(err)
+			}
+		}
+
 		_, err = waitForLB(ctx, lbAPI, zone, ID, d.Timeout(schema.TimeoutUpdate))
 		if err != nil && !httperrors.Is404(err) {
 			return diag.FromErr(err)
@@ -523,8 +603,8 @@ func resourceLbUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 			return diag.FromErr(err)
 		}
 
-		// attach private networks
-		_, err = attachLBPrivateNetworks(ctx, lbAPI, zone, toAttach, ID, d.Timeout(schema.TimeoutUpdate))
+		// attach again private networks with their new configuration
+		_, err = attachLBPrivateNetworks(ctx, lbAPI, zone, toReattach, ID, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -552,7 +632,7 @@ func resourceLbUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 		}
 	}
 
-	return resourceLbRead(ctx, d, m)
+	return append(diags, resourceLbRead(ctx, d, m)...)
 }
 
 func resourceLbDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -615,3 +695,18 @@ func resourceLbDelete(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	return nil
 }
+
+func subscribeToLB(ctx context.Context, lbAPI *lbSDK.ZonedAPI, zone scw.Zone, lbID string, subscriberID string, timeout time.Duration) error {
+	_, err := lbAPI.SubscribeToLB(&lbSDK.ZonedAPISubscribeToLBRequest{
+		Zone:         zone,
+		LBID:         lbID,
+		SubscriberID: subscriberID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("couldn't subscribe %s to load balancer: %w", subscriberID, err)
+	}
+
+	_, err = waitForLB(ctx, lbAPI, zone, lbID, timeout)
+
+	return err
+}
@@ -462,6 +462,141 @@ func TestAccLB_WithPrivateNetworksOnDHCPConfig(t *testing.T) {
 	})
 }
 
+func TestAccLB_SwitchPrivateNetwork(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			instancechecks.IsServerDestroyed(tt),
+			isLbDestroyed(tt),
+			isBackendDestroyed(tt),
+			vpcchecks.CheckPrivateNetworkDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLBSwitchPrivateNetworkConfig([]string{"pn01"}, "pn01"),
+				Check: resource.ComposeTestCheckFunc(
+					isLbPresent(tt, "scaleway_lb.main"),
+					isBackendPresent(tt, "scaleway_lb_backend.main"),
+					resource.TestCheckResourceAttr("scaleway_lb.main", "private_network.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"scaleway_lb.main", "private_network.0.private_network_id",
+						"scaleway_vpc_private_network.pn01", "id"),
+					resource.TestCheckResourceAttrPair(
+						"scaleway_lb_backend.main", "server_ips.0",
+						"data.scaleway_ipam_ip.pn01", "address"),
+				),
+			},
+			{
+				// The backend still targets the server through pn01, detaching it is refused
+				Config:      testAccLBSwitchPrivateNetworkConfig([]string{"pn02"}, "pn02"),
+				ExpectError: regexp.MustCompile("still targets servers"),
+			},
+			{
+				// Both private networks are attached while the backend is moved to pn02
+				Config: testAccLBSwitchPrivateNetworkConfig([]string{"pn01", "pn02"}, "pn02"),
+				Check: resource.ComposeTestCheckFunc(
+					isLbPresent(tt, "scaleway_lb.main"),
+					resource.TestCheckResourceAttr("scaleway_lb.main", "private_network.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"scaleway_lb_backend.main", "server_ips.0",
+						"data.scaleway_ipam_ip.pn02", "address"),
+				),
+			},
+			{
+				Config: testAccLBSwitchPrivateNetworkConfig([]string{"pn02"}, "pn02"),
+				Check: resource.ComposeTestCheckFunc(
+					isLbPresent(tt, "scaleway_lb.main"),
+					isBackendPresent(tt, "scaleway_lb_backend.main"),
+					resource.TestCheckResourceAttr("scaleway_lb.main", "private_network.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"scaleway_lb.main", "private_network.0.private_network_id",
+						"scaleway_vpc_private_network.pn02", "id"),
+					resource.TestCheckResourceAttr("scaleway_lb.main",
+						"private_network.0.status", lbSDK.PrivateNetworkStatusReady.String()),
+					resource.TestCheckResourceAttrPair(
+						"scaleway_lb_backend.main", "server_ips.0",
+						"data.scaleway_ipam_ip.pn02", "address"),
+				),
+			},
+		},
+	})
+}
+
+// testAccLBSwitchPrivateNetworkConfig attaches the load-balancer to the given private networks
+// and targets the server through backendPN
+func testAccLBSwitchPrivateNetworkConfig(pns []string, backendPN string) string {
+	privateNetworks := ""
+	for _, pn := range pns {
+		privateNetworks += fmt.Sprintf(`
+			private_network {
+				private_network_id = scaleway_vpc_private_network.%s.id
+			}
+		`, pn)
+	}
+
+	return fmt.Sprintf(`
+		resource scaleway_vpc_private_network pn01 {
+			name = "test-lb-switch-private-network-01"
+		}
+
+		resource scaleway_vpc_private_network pn02 {
+			name = "test-lb-switch-private-network-02"
+		}
+
+		resource scaleway_instance_server main {
+			name  = "test-lb-switch-private-network"
+			type  = "DEV1-S"
+			image = "ubuntu_jammy"
+
+			private_network {
+				pn_id = scaleway_vpc_private_network.pn01.id
+			}
+
+			private_network {
+				pn_id = scaleway_vpc_private_network.pn02.id
+			}
+		}
+
+		data scaleway_ipam_ip pn01 {
+			resource {
+				id   = scaleway_instance_server.main.id
+				type = "instance_server"
+			}
+			private_network_id = scaleway_vpc_private_network.pn01.id
+			type               = "ipv4"
+		}
+
+		data scaleway_ipam_ip pn02 {
+			resource {
+				id   = scaleway_instance_server.main.id
+				type = "instance_server"
+			}
+			private_network_id = scaleway_vpc_private_network.pn02.id
+			type               = "ipv4"
+		}
+
+		resource scaleway_lb_ip main {}
+
+		resource scaleway_lb main {
+			ip_id = scaleway_lb_ip.main.id
+			name  = "test-lb-switch-private-network"
+			type  = "LB-S"
+			%[1]s
+		}
+
+		resource scaleway_lb_backend main {
+			lb_id            = scaleway_lb.main.id
+			name             = "backend"
+			forward_protocol = "tcp"
+			forward_port     = 80
+			server_ips       = [data.scaleway_ipam_ip.%[2]s.address]
+		}
+	`, privateNetworks, backendPN)
+}
+
 func TestAccLB_WithPrivateNetworksIPAMIDs(t *testing.T) {
 	tt := acctest.NewTestTools(t)
 	defer tt.Cleanup()
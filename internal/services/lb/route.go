@@ -3,6 +3,7 @@ package lb
 import (
 	"context"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
@@ -25,9 +26,10 @@ func ResourceRoute() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultLbLbTimeout),
 		},
-		SchemaVersion: 1,
+		SchemaVersion: 2,
 		StateUpgraders: []schema.StateUpgrader{
 			{Version: 0, Type: lbUpgradeV1SchemaType(), Upgrade: UpgradeStateV1Func},
+			{Version: 1, Type: lbRouteUpgradeV2SchemaType(), Upgrade: RouteUpgradeStateV2Func},
 		},
 		Schema: map[string]*schema.Schema{
 			"frontend_id": {
@@ -44,16 +46,28 @@ func ResourceRoute() *schema.Resource {
 				Description:      "The backend ID destination of redirection",
 			},
 			"match_sni": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				Description:   "Server Name Indication TLS extension field from an incoming connection made via an SSL/TLS transport layer",
-				ConflictsWith: []string{"match_host_header"},
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Server Name Indication TLS extension field from an incoming connection made via an SSL/TLS transport layer",
+				ExactlyOneOf: []string{"match_sni", "match_host_header", "match_path_begin"},
 			},
 			"match_host_header": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				Description:   "Specifies the host of the server to which the request is being sent",
-				ConflictsWith: []string{"match_sni"},
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Specifies the host of the server to which the request is being sent",
+				ExactlyOneOf: []string{"match_sni", "match_host_header", "match_path_begin"},
+			},
+			"match_path_begin": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Value to match in the URL beginning path from an incoming request",
+				ExactlyOneOf: []string{"match_sni", "match_host_header", "match_path_begin"},
+			},
+			"match_subdomains": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, all subdomains of the SNI or host header will match",
 			},
 			"created_at": {
 				Type:        schema.TypeString,
@@ -93,10 +107,7 @@ func resourceLbRouteCreate(ctx context.Context, d *schema.ResourceData, m interf
 		Zone:       frontZone,
 		FrontendID: frontID,
 		BackendID:  backID,
-		Match: &lbSDK.RouteMatch{
-			Sni:        types.ExpandStringPtr(d.Get("match_sni")),
-			HostHeader: types.ExpandStringPtr(d.Get("match_host_header")),
-		},
+		Match:      expandLbRouteMatch(d),
 	}
 
 	route, err := lbAPI.CreateRoute(createReq, scw.WithContext(ctx))
@@ -133,6 +144,8 @@ func resourceLbRouteRead(ctx context.Context, d *schema.ResourceData, m interfac
 	_ = d.Set("backend_id", zonal.NewIDString(zone, route.BackendID))
 	_ = d.Set("match_sni", types.FlattenStringPtr(route.Match.Sni))
 	_ = d.Set("match_host_header", types.FlattenStringPtr(route.Match.HostHeader))
+	_ = d.Set("match_path_begin", types.FlattenStringPtr(route.Match.PathBegin))
+	_ = d.Set("match_subdomains", route.Match.MatchSubdomains)
 	_ = d.Set("created_at", types.FlattenTime(route.CreatedAt))
 	_ = d.Set("updated_at", types.FlattenTime(route.UpdatedAt))
 
@@ -158,10 +171,7 @@ func resourceLbRouteUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		Zone:      zone,
 		RouteID:   ID,
 		BackendID: backID,
-		Match: &lbSDK.RouteMatch{
-			Sni:        types.ExpandStringPtr(d.Get("match_sni")),
-			HostHeader: types.ExpandStringPtr(d.Get("match_host_header")),
-		},
+		Match:     expandLbRouteMatch(d),
 	}
 
 	_, err = lbAPI.UpdateRoute(req, scw.WithContext(ctx))
@@ -189,3 +199,37 @@ func resourceLbRouteDelete(ctx context.Context, d *schema.ResourceData, m interf
 
 	return nil
 }
+
+func expandLbRouteMatch(d *schema.ResourceData) *lbSDK.RouteMatch {
+	return &lbSDK.RouteMatch{
+		Sni:             types.ExpandStringPtr(d.Get("match_sni")),
+		HostHeader:      types.ExpandStringPtr(d.Get("match_host_header")),
+		PathBegin:       types.ExpandStringPtr(d.Get("match_path_begin")),
+		MatchSubdomains: d.Get("match_subdomains").(bool),
+	}
+}
+
+func lbRouteUpgradeV2SchemaType() cty.Type {
+	return cty.Object(map[string]cty.Type{
+		"id":                cty.String,
+		"frontend_id":       cty.String,
+		"backend_id":        cty.String,
+		"match_sni":         cty.String,
+		"match_host_header": cty.String,
+		"created_at":        cty.String,
+		"updated_at":        cty.String,
+	})
+}
+
+// RouteUpgradeStateV2Func sets the match fields added to routes, so that existing routes keep matching on their SNI or host header only
+func RouteUpgradeStateV2Func(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if _, exist := rawState["match_path_begin"]; !exist {
+		rawState["match_path_begin"] = ""
+	}
+
+	if _, exist := rawState["match_subdomains"]; !exist {
+		rawState["match_subdomains"] = false
+	}
+
+	return rawState, nil
+}
@@ -1,7 +1,9 @@
 package lb_test
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -10,6 +12,8 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/lb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccRoute_WithSNI(t *testing.T) {
@@ -100,6 +104,105 @@ func TestAccRoute_WithHostHeader(t *testing.T) {
 	})
 }
 
+func TestAccRoute_WithPathBegin(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	config := `
+					resource scaleway_lb_ip ip01 {}
+					resource scaleway_lb lb01 {
+						ip_id = scaleway_lb_ip.ip01.id
+						name = "test-lb-route-path"
+						type = "lb-s"
+					}
+					resource scaleway_lb_backend bkd01 {
+						lb_id = scaleway_lb.lb01.id
+						forward_protocol = "http"
+						forward_port = 80
+						proxy_protocol = "none"
+					}
+					resource scaleway_lb_backend bkd02 {
+						lb_id = scaleway_lb.lb01.id
+						forward_protocol = "http"
+						forward_port = 8080
+						proxy_protocol = "none"
+					}
+					resource scaleway_lb_frontend frt01 {
+						lb_id = scaleway_lb.lb01.id
+						backend_id = scaleway_lb_backend.bkd01.id
+						inbound_port = 80
+					}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      isRouteDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: config + `
+					resource scaleway_lb_route rt01 {
+						frontend_id = scaleway_lb_frontend.frt01.id
+						backend_id = scaleway_lb_backend.bkd02.id
+						match_path_begin = "/api"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isRoutePresent(tt, "scaleway_lb_route.rt01"),
+					resource.TestCheckResourceAttr("scaleway_lb_route.rt01", "match_path_begin", "/api"),
+					resource.TestCheckResourceAttr("scaleway_lb_route.rt01", "match_host_header", ""),
+					resource.TestCheckResourceAttr("scaleway_lb_route.rt01", "match_subdomains", "false"),
+				),
+			},
+			{
+				// Switching the match to a host header with its subdomains is done in place
+				Config: config + `
+					resource scaleway_lb_route rt01 {
+						frontend_id = scaleway_lb_frontend.frt01.id
+						backend_id = scaleway_lb_backend.bkd02.id
+						match_host_header = "scaleway.com"
+						match_subdomains = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isRoutePresent(tt, "scaleway_lb_route.rt01"),
+					resource.TestCheckResourceAttr("scaleway_lb_route.rt01", "match_path_begin", ""),
+					resource.TestCheckResourceAttr("scaleway_lb_route.rt01", "match_host_header", "scaleway.com"),
+					resource.TestCheckResourceAttr("scaleway_lb_route.rt01", "match_subdomains", "true"),
+				),
+			},
+			{
+				Config: config + `
+					resource scaleway_lb_route rt01 {
+						frontend_id = scaleway_lb_frontend.frt01.id
+						backend_id = scaleway_lb_backend.bkd02.id
+						match_host_header = "scaleway.com"
+						match_path_begin = "/api"
+					}
+				`,
+				ExpectError: regexp.MustCompile("only one of `match_host_header,match_path_begin,match_sni` can be\\s+specified"),
+			},
+		},
+	})
+}
+
+func TestRouteUpgradeStateV2Func(t *testing.T) {
+	v1State := map[string]interface{}{
+		"id":                "fr-par-1/11111111-1111-1111-1111-111111111111",
+		"frontend_id":       "fr-par-1/22222222-2222-2222-2222-222222222222",
+		"backend_id":        "fr-par-1/33333333-3333-3333-3333-333333333333",
+		"match_host_header": "host.scaleway.com",
+		"match_sni":         "",
+	}
+
+	v2State, err := lb.RouteUpgradeStateV2Func(context.Background(), v1State, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "host.scaleway.com", v2State["match_host_header"])
+	assert.Equal(t, "", v2State["match_path_begin"])
+	assert.Equal(t, false, v2State["match_subdomains"])
+}
+
 func isRoutePresent(tt *acctest.TestTools, n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -46,6 +46,14 @@ func DataSourceRoutes() *schema.Resource {
 							Computed: true,
 							Type:     schema.TypeString,
 						},
+						"match_path_begin": {
+							Computed: true,
+							Type:     schema.TypeString,
+						},
+						"match_subdomains": {
+							Computed: true,
+							Type:     schema.TypeBool,
+						},
 						"created_at": {
 							Computed: true,
 							Type:     schema.TypeString,
@@ -94,6 +102,8 @@ func DataSourceLbRoutesRead(ctx context.Context, d *schema.ResourceData, m inter
 		rawRoute["update_at"] = types.FlattenTime(route.UpdatedAt)
 		rawRoute["match_sni"] = types.FlattenStringPtr(route.Match.Sni)
 		rawRoute["match_host_header"] = types.FlattenStringPtr(route.Match.HostHeader)
+		rawRoute["match_path_begin"] = types.FlattenStringPtr(route.Match.PathBegin)
+		rawRoute["match_subdomains"] = route.Match.MatchSubdomains
 
 		routes = append(routes, rawRoute)
 	}
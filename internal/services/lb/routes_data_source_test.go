@@ -78,6 +78,8 @@ func TestAccDataSourceRoutes_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet("data.scaleway_lb_routes.by_frontendID", "routes.0.id"),
 					resource.TestCheckResourceAttrSet("data.scaleway_lb_routes.by_frontendID", "routes.1.id"),
+					resource.TestCheckResourceAttr("data.scaleway_lb_routes.by_frontendID", "routes.0.match_path_begin", ""),
+					resource.TestCheckResourceAttr("data.scaleway_lb_routes.by_frontendID", "routes.0.match_subdomains", "false"),
 				),
 			},
 		},
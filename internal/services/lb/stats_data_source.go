@@ -0,0 +1,124 @@
+package lb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func DataSourceStats() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceLbStatsRead,
+		Schema: map[string]*schema.Schema{
+			"lb_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				Description:      "The ID of the load-balancer",
+			},
+			"backend_servers": backendServerStatsSchema(),
+			"all_healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the last health check of every backend server passed, false when there is no server",
+			},
+			"server_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of backend servers",
+			},
+			"zone": zonal.Schema(),
+		},
+	}
+}
+
+func DataSourceLbStatsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	lbAPI, zone, err := lbAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lbID := locality.ExpandID(d.Get("lb_id"))
+
+	res, err := lbAPI.ListBackendStats(&lbSDK.ZonedAPIListBackendStatsRequest{
+		Zone: zone,
+		LBID: lbID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(zonal.NewIDString(zone, lbID))
+	_ = d.Set("backend_servers", flattenLbBackendServerStats(zone, res.BackendServersStats))
+	_ = d.Set("all_healthy", AreBackendServersHealthy(res.BackendServersStats))
+	_ = d.Set("server_count", len(res.BackendServersStats))
+	_ = d.Set("zone", zone)
+
+	return nil
+}
+
+func backendServerStatsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The statistics of the backend servers",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"backend_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the backend of the server",
+				},
+				"instance_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the load-balancer instance running the health checks",
+				},
+				"ip": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The IP address of the backend server",
+				},
+				"server_state": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The operational state of the backend server",
+				},
+				"server_state_changed_at": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The date of the last change of the operational state, not of the last health check (Format ISO 8601)",
+				},
+				"last_health_check_status": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The status of the last health check of the backend server",
+				},
+			},
+		},
+	}
+}
+
+func flattenLbBackendServerStats(zone scw.Zone, stats []*lbSDK.BackendServerStats) interface{} {
+	flattened := []map[string]interface{}(nil)
+
+	for _, stat := range stats {
+		flattened = append(flattened, map[string]interface{}{
+			"backend_id":               zonal.NewIDString(zone, stat.BackendID),
+			"instance_id":              stat.InstanceID,
+			"ip":                       stat.IP,
+			"server_state":             stat.ServerState.String(),
+			"server_state_changed_at":  types.FlattenTime(stat.ServerStateChangedAt),
+			"last_health_check_status": stat.LastHealthCheckStatus.String(),
+		})
+	}
+
+	return flattened
+}
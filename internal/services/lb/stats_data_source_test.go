@@ -0,0 +1,70 @@
+package lb_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func TestAccDataSourceStats_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      isLbDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource scaleway_lb_ip ip01 {}
+					resource scaleway_lb lb01 {
+						ip_id = scaleway_lb_ip.ip01.id
+						name = "test-lb-stats"
+						type = "lb-s"
+					}
+					resource scaleway_lb_backend bkd01 {
+						lb_id = scaleway_lb.lb01.id
+						name  = "tf-backend-stats"
+						forward_protocol = "tcp"
+						forward_port = 80
+						server_ips = ["192.0.2.1"]
+					}
+					resource scaleway_lb_backend bkd02 {
+						lb_id = scaleway_lb.lb01.id
+						name  = "tf-backend-stats-empty"
+						forward_protocol = "tcp"
+						forward_port = 80
+					}
+
+					data scaleway_lb_stats main {
+						lb_id = scaleway_lb.lb01.id
+						depends_on = [scaleway_lb_backend.bkd01, scaleway_lb_backend.bkd02]
+					}
+					data scaleway_lb_backend_stats main {
+						backend_id = scaleway_lb_backend.bkd01.id
+					}
+					data scaleway_lb_backend_stats empty {
+						backend_id = scaleway_lb_backend.bkd02.id
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.scaleway_lb_stats.main", "server_count", "1"),
+					resource.TestCheckResourceAttr("data.scaleway_lb_stats.main", "backend_servers.0.ip", "192.0.2.1"),
+					resource.TestCheckResourceAttrPair("data.scaleway_lb_stats.main", "backend_servers.0.backend_id", "scaleway_lb_backend.bkd01", "id"),
+					resource.TestCheckResourceAttrSet("data.scaleway_lb_stats.main", "backend_servers.0.server_state"),
+					resource.TestCheckResourceAttrSet("data.scaleway_lb_stats.main", "backend_servers.0.last_health_check_status"),
+					// No server answers on the documentation IP
+					resource.TestCheckResourceAttr("data.scaleway_lb_stats.main", "all_healthy", "false"),
+
+					resource.TestCheckResourceAttrPair("data.scaleway_lb_backend_stats.main", "lb_id", "scaleway_lb.lb01", "id"),
+					resource.TestCheckResourceAttr("data.scaleway_lb_backend_stats.main", "server_count", "1"),
+					resource.TestCheckResourceAttr("data.scaleway_lb_backend_stats.main", "backend_servers.0.ip", "192.0.2.1"),
+
+					resource.TestCheckResourceAttr("data.scaleway_lb_backend_stats.empty", "server_count", "0"),
+					resource.TestCheckResourceAttr("data.scaleway_lb_backend_stats.empty", "all_healthy", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,205 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+)
+
+func ResourceSubscriber() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLbSubscriberCreate,
+		ReadContext:   resourceLbSubscriberRead,
+		UpdateContext: resourceLbSubscriberUpdate,
+		DeleteContext: resourceLbSubscriberDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultLbLbTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the subscriber",
+			},
+			"email_config": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"email_config", "webhook_config"},
+				Description:  "Email address notified when a backend server goes down",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The email address to send the alerts to",
+						},
+					},
+				},
+			},
+			"webhook_config": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Webhook called when a backend server goes down",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uri": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Description:  "The URI receiving the POST requests",
+						},
+					},
+				},
+			},
+			"zone":       zonal.Schema(),
+			"project_id": account.ProjectIDSchema(),
+		},
+	}
+}
+
+func resourceLbSubscriberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	lbAPI, zone, err := lbAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	projectID, _, err := meta.ExtractProjectID(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subscriber, err := lbAPI.CreateSubscriber(&lbSDK.ZonedAPICreateSubscriberRequest{
+		Zone:          zone,
+		Name:          types.ExpandOrGenerateString(d.Get("name"), "lb-subscriber"),
+		EmailConfig:   expandLbSubscriberEmailConfig(d.Get("email_config")),
+		WebhookConfig: expandLbSubscriberWebhookConfig(d.Get("webhook_config")),
+		ProjectID:     &projectID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(zonal.NewIDString(zone, subscriber.ID))
+	_ = d.Set("project_id", projectID)
+
+	return resourceLbSubscriberRead(ctx, d, m)
+}
+
+func resourceLbSubscriberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	lbAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subscriber, err := lbAPI.GetSubscriber(&lbSDK.ZonedAPIGetSubscriberRequest{
+		Zone:         zone,
+		SubscriberID: ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	// the API does not return the project of a subscriber, it is only looked up when missing from the state, after an import
+	if d.Get("project_id").(string) == "" {
+		projectID, err := findLbSubscriberProjectID(ctx, lbAPI, zone, subscriber, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_ = d.Set("project_id", projectID)
+	}
+
+	_ = d.Set("name", subscriber.Name)
+	_ = d.Set("email_config", flattenLbSubscriberEmailConfig(subscriber.EmailConfig))
+	_ = d.Set("webhook_config", flattenLbSubscriberWebhookConfig(subscriber.WebhookConfig))
+	_ = d.Set("zone", zone.String())
+
+	return nil
+}
+
+func resourceLbSubscriberUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	lbAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("name", "email_config", "webhook_config") {
+		_, err = lbAPI.UpdateSubscriber(&lbSDK.ZonedAPIUpdateSubscriberRequest{
+			Zone:          zone,
+			SubscriberID:  ID,
+			Name:          d.Get("name").(string),
+			EmailConfig:   expandLbSubscriberEmailConfig(d.Get("email_config")),
+			WebhookConfig: expandLbSubscriberWebhookConfig(d.Get("webhook_config")),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceLbSubscriberRead(ctx, d, m)
+}
+
+func resourceLbSubscriberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	lbAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = lbAPI.DeleteSubscriber(&lbSDK.ZonedAPIDeleteSubscriberRequest{
+		Zone:         zone,
+		SubscriberID: ID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// findLbSubscriberProjectID returns the default project of the provider if the subscriber belongs to it
+func findLbSubscriberProjectID(ctx context.Context, lbAPI *lbSDK.ZonedAPI, zone scw.Zone, subscriber *lbSDK.Subscriber, m interface{}) (string, error) {
+	defaultProjectID, exists := meta.ExtractScwClient(m).GetDefaultProjectID()
+	if !exists {
+		return "", fmt.Errorf("cannot find the project of subscriber %s: %w", subscriber.ID, meta.ErrProjectIDNotFound)
+	}
+
+	res, err := lbAPI.ListSubscriber(&lbSDK.ZonedAPIListSubscriberRequest{
+		Zone:      zone,
+		Name:      &subscriber.Name,
+		ProjectID: &defaultProjectID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	for _, sub := range res.Subscribers {
+		if sub.ID == subscriber.ID {
+			return defaultProjectID, nil
+		}
+	}
+
+	return "", fmt.Errorf("subscriber %s does not belong to project %s, set the project_id of the provider to the project of the subscriber to import it", subscriber.ID, defaultProjectID)
+}
@@ -0,0 +1,187 @@
+package lb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/lb"
+)
+
+func TestAccLbSubscriber_Email(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			isLbDestroyed(tt),
+			isLbSubscriberDestroyed(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource scaleway_lb_subscriber main {
+						name = "test-lb-subscriber-email"
+						email_config {
+							email = "ops@example.com"
+						}
+					}
+
+					resource scaleway_lb_ip main {
+					}
+
+					resource scaleway_lb main {
+						ip_id = scaleway_lb_ip.main.id
+						name = "test-lb-subscriber-email"
+						type = "LB-S"
+						subscriber_id = scaleway_lb_subscriber.main.id
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isLbSubscriberPresent(tt, "scaleway_lb_subscriber.main"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "name", "test-lb-subscriber-email"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "email_config.0.email", "ops@example.com"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "webhook_config.#", "0"),
+					resource.TestCheckResourceAttrSet("scaleway_lb_subscriber.main", "project_id"),
+					resource.TestCheckResourceAttrPair("scaleway_lb.main", "subscriber_id", "scaleway_lb_subscriber.main", "id"),
+				),
+			},
+			{
+				Config: `
+					resource scaleway_lb_subscriber main {
+						name = "test-lb-subscriber-email-renamed"
+						email_config {
+							email = "oncall@example.com"
+						}
+					}
+
+					resource scaleway_lb_ip main {
+					}
+
+					resource scaleway_lb main {
+						ip_id = scaleway_lb_ip.main.id
+						name = "test-lb-subscriber-email"
+						type = "LB-S"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isLbSubscriberPresent(tt, "scaleway_lb_subscriber.main"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "name", "test-lb-subscriber-email-renamed"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "email_config.0.email", "oncall@example.com"),
+					resource.TestCheckResourceAttr("scaleway_lb.main", "subscriber_id", ""),
+				),
+			},
+			{
+				ResourceName:      "scaleway_lb_subscriber.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccLbSubscriber_Webhook(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      isLbSubscriberDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource scaleway_lb_subscriber main {
+						name = "test-lb-subscriber-webhook"
+						webhook_config {
+							uri = "https://alerting.example.com/lb"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isLbSubscriberPresent(tt, "scaleway_lb_subscriber.main"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "webhook_config.0.uri", "https://alerting.example.com/lb"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "email_config.#", "0"),
+					resource.TestCheckResourceAttrSet("scaleway_lb_subscriber.main", "project_id"),
+				),
+			},
+			{
+				// Switching from a webhook to an email is done in place
+				Config: `
+					resource scaleway_lb_subscriber main {
+						name = "test-lb-subscriber-webhook"
+						email_config {
+							email = "ops@example.com"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isLbSubscriberPresent(tt, "scaleway_lb_subscriber.main"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "email_config.0.email", "ops@example.com"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "webhook_config.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "scaleway_lb_subscriber.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func isLbSubscriberPresent(tt *acctest.TestTools, n string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		lbAPI, zone, ID, err := lb.NewAPIWithZoneAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = lbAPI.GetSubscriber(&lbSDK.ZonedAPIGetSubscriberRequest{
+			Zone:         zone,
+			SubscriberID: ID,
+		})
+
+		return err
+	}
+}
+
+func isLbSubscriberDestroyed(tt *acctest.TestTools) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "scaleway_lb_subscriber" {
+				continue
+			}
+
+			lbAPI, zone, ID, err := lb.NewAPIWithZoneAndID(tt.Meta, rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			_, err = lbAPI.GetSubscriber(&lbSDK.ZonedAPIGetSubscriberRequest{
+				Zone:         zone,
+				SubscriberID: ID,
+			})
+			if err == nil {
+				return fmt.Errorf("subscriber (%s) still exists", rs.Primary.ID)
+			}
+
+			if !httperrors.Is404(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
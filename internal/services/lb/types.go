@@ -480,3 +480,53 @@ func flattenLBIPIDs(zone scw.Zone, ips []*lb.IP) []string {
 
 	return flattenedIPs
 }
+
+func expandLbSubscriberEmailConfig(raw interface{}) *lb.SubscriberEmailConfig {
+	rawList := raw.([]interface{})
+	if len(rawList) == 0 || rawList[0] == nil {
+		return nil
+	}
+
+	rawMap := rawList[0].(map[string]interface{})
+
+	return &lb.SubscriberEmailConfig{
+		Email: rawMap["email"].(string),
+	}
+}
+
+func flattenLbSubscriberEmailConfig(config *lb.SubscriberEmailConfig) interface{} {
+	if config == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"email": config.Email,
+		},
+	}
+}
+
+func expandLbSubscriberWebhookConfig(raw interface{}) *lb.SubscriberWebhookConfig {
+	rawList := raw.([]interface{})
+	if len(rawList) == 0 || rawList[0] == nil {
+		return nil
+	}
+
+	rawMap := rawList[0].(map[string]interface{})
+
+	return &lb.SubscriberWebhookConfig{
+		URI: rawMap["uri"].(string),
+	}
+}
+
+func flattenLbSubscriberWebhookConfig(config *lb.SubscriberWebhookConfig) interface{} {
+	if config == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"uri": config.URI,
+		},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	mongodbV1 "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1"
 	mongodb "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
@@ -26,6 +27,11 @@ func newAPI(m interface{}) *mongodb.API {
 	return mongodb.NewAPI(meta.ExtractScwClient(m))
 }
 
+// newSnapshotScheduleAPI returns a MongoDB v1 API, the snapshot schedule of an instance can only be updated through it
+func newSnapshotScheduleAPI(m interface{}) *mongodbV1.API {
+	return mongodbV1.NewAPI(meta.ExtractScwClient(m))
+}
+
 // newAPIWithZone returns a new mongoDB API and the zone for a Create request
 func newAPIWithZone(d *schema.ResourceData, m interface{}) (*mongodb.API, scw.Zone, error) {
 	zone, err := meta.ExtractZone(d, m)
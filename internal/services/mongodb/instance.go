@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	mongodbV1 "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1"
 	mongodb "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
@@ -16,6 +17,7 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
 )
 
 func ResourceInstance() *schema.Resource {
@@ -104,6 +106,50 @@ func ResourceInstance() *schema.Resource {
 					"version",
 				},
 			},
+			"private_network": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Private network to expose your MongoDB instance on",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pn_id": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+							DiffSuppressFunc: dsf.Locality,
+							Description:      "The private network ID",
+						},
+						// Computed
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the endpoint",
+						},
+						"ips": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "List of IP addresses of the endpoint",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "TCP port of the endpoint",
+						},
+						"dns_records": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "List of DNS records of the endpoint",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
 			// Computed
 			"public_network": {
 				Type:        schema.TypeList,
@@ -146,6 +192,26 @@ func ResourceInstance() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"snapshot_schedule_frequency_hours": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Number of hours between two automatic snapshots of the instance",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"snapshot_schedule_retention_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Number of days automatic snapshots are kept",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"is_snapshot_schedule_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Defines whether automatic snapshots of the instance are enabled",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -221,9 +287,19 @@ func ResourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 			createReq.Tags = types.ExpandStrings(tags)
 		}
 
-		epSpecs := make([]*mongodb.EndpointSpec, 0, 1)
-		spec := &mongodb.EndpointSpecPublicDetails{}
-		epSpecs = append(epSpecs, &mongodb.EndpointSpec{Public: spec})
+		epSpecs := make([]*mongodb.EndpointSpec, 0, 2)
+
+		privateNetworkSpec := expandPrivateNetwork(d.Get("private_network"))
+		if privateNetworkSpec != nil {
+			epSpecs = append(epSpecs, privateNetworkSpec)
+		}
+
+		// The public endpoint is kept by default, it is only left out when the instance is exposed on a private network alone
+		if _, publicNetworkExists := d.GetOk("public_network"); privateNetworkSpec == nil || publicNetworkExists {
+			spec := &mongodb.EndpointSpecPublicDetails{}
+			epSpecs = append(epSpecs, &mongodb.EndpointSpec{Public: spec})
+		}
+
 		createReq.Endpoints = epSpecs
 
 		res, err = mongodbAPI.CreateInstance(createReq, scw.WithContext(ctx))
@@ -239,9 +315,149 @@ func ResourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
+	if exist {
+		err = configureRestoredInstance(ctx, d, mongodbAPI, res)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, key := range []string{"snapshot_schedule_frequency_hours", "snapshot_schedule_retention_days", "is_snapshot_schedule_enabled"} {
+		if !d.GetRawConfig().GetAttr(key).IsNull() {
+			err = updateSnapshotSchedule(ctx, d, m, res.Region, res.ID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			break
+		}
+	}
+
 	return ResourceInstanceRead(ctx, d, m)
 }
 
+// configureRestoredInstance applies the configuration a snapshot restoration does not take into account:
+// tags, a larger volume and the private network endpoint
+func configureRestoredInstance(ctx context.Context, d *schema.ResourceData, mongodbAPI *mongodb.API, instance *mongodb.Instance) error {
+	if tags, tagsExist := d.GetOk("tags"); tagsExist {
+		_, err := mongodbAPI.UpdateInstance(&mongodb.UpdateInstanceRequest{
+			Region:     instance.Region,
+			InstanceID: instance.ID,
+			Tags:       types.ExpandUpdatedStringsPtr(tags),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+	}
+
+	if volumeSize, volumeSizeExist := d.GetOk("volume_size_in_gb"); volumeSizeExist {
+		size := scw.Size(uint64(volumeSize.(int)) * uint64(scw.GB))
+
+		if instance.Volume == nil || size > instance.Volume.Size {
+			_, err := mongodbAPI.UpgradeInstance(&mongodb.UpgradeInstanceRequest{
+				Region:     instance.Region,
+				InstanceID: instance.ID,
+				VolumeSize: &size,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+
+			_, err = waitForInstance(ctx, mongodbAPI, instance.Region, instance.ID, d.Timeout(schema.TimeoutCreate))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if privateNetworkSpec := expandPrivateNetwork(d.Get("private_network")); privateNetworkSpec != nil {
+		_, err := mongodbAPI.CreateEndpoint(&mongodb.CreateEndpointRequest{
+			Region:     instance.Region,
+			InstanceID: instance.ID,
+			Endpoint:   privateNetworkSpec,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		_, err = waitForInstance(ctx, mongodbAPI, instance.Region, instance.ID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateSnapshotSchedule sets the automatic snapshots configuration of the instance, only the configured fields are sent
+func updateSnapshotSchedule(ctx context.Context, d *schema.ResourceData, m interface{}, region scw.Region, instanceID string) error {
+	req := &mongodbV1.UpdateInstanceRequest{
+		Region:     region,
+		InstanceID: instanceID,
+	}
+
+	if frequency, ok := d.GetOk("snapshot_schedule_frequency_hours"); ok {
+		req.SnapshotScheduleFrequencyHours = scw.Uint32Ptr(uint32(frequency.(int)))
+	}
+
+	if retention, ok := d.GetOk("snapshot_schedule_retention_days"); ok {
+		req.SnapshotScheduleRetentionDays = scw.Uint32Ptr(uint32(retention.(int)))
+	}
+
+	if enabled, ok := d.GetOkExists("is_snapshot_schedule_enabled"); ok { //nolint:staticcheck
+		req.IsSnapshotScheduleEnabled = scw.BoolPtr(enabled.(bool))
+	}
+
+	_, err := newSnapshotScheduleAPI(m).UpdateInstance(req, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForInstance(ctx, newAPI(m), region, instanceID, d.Timeout(schema.TimeoutUpdate))
+
+	return err
+}
+
+// updatePrivateNetworkEndpoint replaces the private network endpoint of the instance, endpoints cannot be updated in place
+func updatePrivateNetworkEndpoint(ctx context.Context, d *schema.ResourceData, mongodbAPI *mongodb.API, region scw.Region, instanceID string) error {
+	oldPNs, _ := d.GetChange("private_network")
+	if rawOldPNs := oldPNs.([]interface{}); len(rawOldPNs) > 0 && rawOldPNs[0] != nil {
+		endpointID := rawOldPNs[0].(map[string]interface{})["id"].(string)
+		if endpointID != "" {
+			err := mongodbAPI.DeleteEndpoint(&mongodb.DeleteEndpointRequest{
+				Region:     region,
+				EndpointID: endpointID,
+			}, scw.WithContext(ctx))
+			if err != nil && !httperrors.Is404(err) {
+				return err
+			}
+
+			_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	privateNetworkSpec := expandPrivateNetwork(d.Get("private_network"))
+	if privateNetworkSpec == nil {
+		return nil
+	}
+
+	_, err := mongodbAPI.CreateEndpoint(&mongodb.CreateEndpointRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		Endpoint:   privateNetworkSpec,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutUpdate))
+
+	return err
+}
+
 func ResourceInstanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mongodbAPI, region, ID, err := NewAPIWithRegionAndID(m, d.Id())
 	if err != nil {
@@ -278,11 +494,20 @@ func ResourceInstanceRead(ctx context.Context, d *schema.ResourceData, m interfa
 		_ = d.Set("volume_size_in_gb", int(instance.Volume.Size/scw.GB))
 	}
 
+	if instance.SnapshotSchedule != nil {
+		_ = d.Set("snapshot_schedule_frequency_hours", int(instance.SnapshotSchedule.FrequencyHours))
+		_ = d.Set("snapshot_schedule_retention_days", int(instance.SnapshotSchedule.RetentionDays))
+		_ = d.Set("is_snapshot_schedule_enabled", instance.SnapshotSchedule.Enabled)
+	}
+
 	publicNetworkEndpoint, publicNetworkExists := flattenPublicNetwork(instance.Endpoints)
 	if publicNetworkExists {
 		_ = d.Set("public_network", publicNetworkEndpoint)
 	}
 
+	privateNetworkEndpoint, _ := flattenPrivateNetwork(instance.Region, instance.Endpoints)
+	_ = d.Set("private_network", privateNetworkEndpoint)
+
 	if len(instance.Settings) > 0 {
 		settingsMap := make(map[string]string)
 		for _, setting := range instance.Settings {
@@ -366,6 +591,24 @@ func ResourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		}
 	}
 
+	if d.HasChanges("snapshot_schedule_frequency_hours", "snapshot_schedule_retention_days", "is_snapshot_schedule_enabled") {
+		err = updateSnapshotSchedule(ctx, d, m, region, ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	////////////////////
+	// Update endpoints
+	////////////////////
+
+	if d.HasChange("private_network") {
+		err = updatePrivateNetworkEndpoint(ctx, d, mongodbAPI, region, ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	////////////////////
 	// Update user
 	////////////////////
@@ -1,7 +1,14 @@
 package mongodb
 
 import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	mongodb "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 )
 
 func flattenPublicNetwork(endpoints []*mongodb.Endpoint) (interface{}, bool) {
@@ -23,3 +30,86 @@ func flattenPublicNetwork(endpoints []*mongodb.Endpoint) (interface{}, bool) {
 
 	return publicFlat, len(publicFlat) != 0
 }
+
+func flattenPrivateNetwork(region scw.Region, endpoints []*mongodb.Endpoint) (interface{}, bool) {
+	privateFlat := []map[string]interface{}(nil)
+
+	for _, endpoint := range endpoints {
+		if endpoint.PrivateNetwork == nil {
+			continue
+		}
+
+		ips := []string(nil)
+		for _, ip := range endpoint.IPs {
+			ips = append(ips, ip.String())
+		}
+
+		privateFlat = append(privateFlat, map[string]interface{}{
+			"pn_id":       regional.NewIDString(region, endpoint.PrivateNetwork.PrivateNetworkID),
+			"id":          endpoint.ID,
+			"ips":         ips,
+			"port":        int(endpoint.Port),
+			"dns_records": endpoint.DNSRecords,
+		})
+
+		break
+	}
+
+	return privateFlat, len(privateFlat) != 0
+}
+
+func expandPrivateNetwork(data interface{}) *mongodb.EndpointSpec {
+	rawPNs := data.([]interface{})
+	if len(rawPNs) == 0 || rawPNs[0] == nil {
+		return nil
+	}
+
+	return &mongodb.EndpointSpec{
+		PrivateNetwork: &mongodb.EndpointSpecPrivateNetworkDetails{
+			PrivateNetworkID: locality.ExpandID(rawPNs[0].(map[string]interface{})["pn_id"]),
+		},
+	}
+}
+
+// expandUserRoles expands the roles of a user, each role is granted either on a database or on all of them
+func expandUserRoles(data interface{}) ([]*mongodb.UserRole, error) {
+	roles := []*mongodb.UserRole{}
+
+	for _, rawRole := range data.(*schema.Set).List() {
+		role := rawRole.(map[string]interface{})
+		databaseName := role["database_name"].(string)
+		anyDatabase := role["any_database"].(bool)
+
+		if (databaseName == "") == !anyDatabase {
+			return nil, errors.New("exactly one of database_name and any_database must be set for each role")
+		}
+
+		userRole := &mongodb.UserRole{
+			Role: mongodb.UserRoleRole(role["role"].(string)),
+		}
+
+		if anyDatabase {
+			userRole.AnyDatabase = scw.BoolPtr(true)
+		} else {
+			userRole.Database = scw.StringPtr(databaseName)
+		}
+
+		roles = append(roles, userRole)
+	}
+
+	return roles, nil
+}
+
+func flattenUserRoles(roles []*mongodb.UserRole) []map[string]interface{} {
+	rolesFlat := make([]map[string]interface{}, 0, len(roles))
+
+	for _, role := range roles {
+		rolesFlat = append(rolesFlat, map[string]interface{}{
+			"role":          role.Role.String(),
+			"database_name": types.FlattenStringPtr(role.Database),
+			"any_database":  role.AnyDatabase != nil && *role.AnyDatabase,
+		})
+	}
+
+	return rolesFlat
+}
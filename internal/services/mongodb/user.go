@@ -0,0 +1,343 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	mongodb "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceUserCreate,
+		ReadContext:   ResourceUserRead,
+		UpdateContext: ResourceUserUpdate,
+		DeleteContext: ResourceUserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+			Read:    schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+			Update:  schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+			Delete:  schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+			Default: schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "Instance on which the user is created",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the user",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the user",
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "List of roles assigned to the user, along with the database where each role is granted",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: verify.ValidateEnum[mongodb.UserRoleRole](),
+							Description:      "Name of the preset role",
+						},
+						"database_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of the database on which the role is granted",
+						},
+						"any_database": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Grant the role on all the databases of the instance",
+						},
+					},
+				},
+			},
+			// Common
+			"region": regional.Schema(),
+		},
+	}
+}
+
+func ResourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	mongodbAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the instance ID is zonal, the user belongs to the region of its zone
+	instanceID := locality.ExpandID(d.Get("instance_id"))
+	if zone, _, err := zonal.ParseID(d.Get("instance_id").(string)); err == nil {
+		region, err = zone.Region()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	user, err := mongodbAPI.CreateUser(&mongodb.CreateUserRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		Name:       d.Get("name").(string),
+		Password:   d.Get("password").(string),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ResourceUserID(region, instanceID, user.Name))
+
+	_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if rawRoles, rolesExist := d.GetOk("roles"); rolesExist {
+		roles, err := expandUserRoles(rawRoles)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = mongodbAPI.SetUserRole(&mongodb.SetUserRoleRequest{
+			Region:     region,
+			InstanceID: instanceID,
+			UserName:   user.Name,
+			Roles:      roles,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ResourceUserRead(ctx, d, m)
+}
+
+func ResourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	mongodbAPI := newAPI(m)
+
+	region, instanceID, userName, err := ResourceUserParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	res, err := mongodbAPI.ListUsers(&mongodb.ListUsersRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		Name:       &userName,
+	}, scw.WithContext(ctx), scw.WithAllPages())
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	var user *mongodb.User
+
+	for _, u := range res.Users {
+		if u.Name == userName {
+			user = u
+
+			break
+		}
+	}
+
+	if user == nil {
+		tflog.Warn(ctx, fmt.Sprintf("couldn't find user with name: [%s]", userName))
+		d.SetId("")
+
+		return nil
+	}
+
+	// the instance ID set in the configuration is kept, it is zonal when it comes from scaleway_mongodb_instance
+	if locality.ExpandID(d.Get("instance_id")) != instanceID {
+		_ = d.Set("instance_id", regional.NewIDString(region, instanceID))
+	}
+
+	_ = d.Set("name", user.Name)
+	_ = d.Set("roles", flattenUserRoles(user.Roles))
+	_ = d.Set("region", string(region))
+
+	return nil
+}
+
+func ResourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	mongodbAPI := newAPI(m)
+
+	region, instanceID, userName, err := ResourceUserParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("password") {
+		_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = mongodbAPI.UpdateUser(&mongodb.UpdateUserRequest{
+			Region:     region,
+			InstanceID: instanceID,
+			Name:       userName,
+			Password:   types.ExpandStringPtr(d.Get("password")),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("roles") {
+		roles, err := expandUserRoles(d.Get("roles"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = mongodbAPI.SetUserRole(&mongodb.SetUserRoleRequest{
+			Region:     region,
+			InstanceID: instanceID,
+			UserName:   userName,
+			Roles:      roles,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("roles") {
+		roles, err := expandUserRoles(d.Get("roles"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = mongodbAPI.SetUserRole(&mongodb.SetUserRoleRequest{
+			Region:     region,
+			InstanceID: instanceID,
+			UserName:   userName,
+			Roles:      roles,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ResourceUserRead(ctx, d, m)
+}
+
+func ResourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	mongodbAPI := newAPI(m)
+
+	region, instanceID, userName, err := ResourceUserParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	err = mongodbAPI.DeleteUser(&mongodb.DeleteUserRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		Name:       userName,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// ResourceUserID builds the resource identifier
+// The resource identifier format is "Region/InstanceId/UserName"
+func ResourceUserID(region scw.Region, instanceID string, userName string) (resourceID string) {
+	return fmt.Sprintf("%s/%s/%s", region, instanceID, userName)
+}
+
+// ResourceUserParseID extracts region, instance ID and username from the resource identifier.
+// The resource identifier format is "Region/InstanceId/UserName"
+func ResourceUserParseID(resourceID string) (region scw.Region, instanceID string, userName string, err error) {
+	idParts := strings.Split(resourceID, "/")
+	if len(idParts) != 3 {
+		return "", "", "", fmt.Errorf("can't parse user resource id: %s", resourceID)
+	}
+
+	return scw.Region(idParts[0]), idParts[1], idParts[2], nil
+}
@@ -0,0 +1,182 @@
+package mongodb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	mongodbSDK "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/mongodb"
+)
+
+func TestAccMongoDBUser_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	instanceConfig := `
+		resource scaleway_mongodb_instance main {
+			name = "test-mongodb-user"
+			version = "7.0.12"
+			node_type = "MGDB-PLAY2-NANO"
+			node_number = 1
+			user_name = "my_initial_user"
+			password = "thiZ_is_v&ry_s3cret"
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: instanceConfig + `
+					resource scaleway_mongodb_user app {
+						instance_id = scaleway_mongodb_instance.main.id
+						name = "app"
+						password = "thiZ_is_v&ry_s3cret_t00"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isMongoDBUserPresent(tt, "scaleway_mongodb_user.app"),
+					resource.TestCheckResourceAttrPair("scaleway_mongodb_user.app", "instance_id", "scaleway_mongodb_instance.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_mongodb_user.app", "name", "app"),
+					resource.TestCheckResourceAttr("scaleway_mongodb_user.app", "password", "thiZ_is_v&ry_s3cret_t00"),
+				),
+			},
+			{
+				// Changing the password is done in place
+				Config: instanceConfig + `
+					resource scaleway_mongodb_user app {
+						instance_id = scaleway_mongodb_instance.main.id
+						name = "app"
+						password = "An0ther_v&ry_s3cret"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isMongoDBUserPresent(tt, "scaleway_mongodb_user.app"),
+					resource.TestCheckResourceAttr("scaleway_mongodb_user.app", "password", "An0ther_v&ry_s3cret"),
+				),
+			},
+			{
+				// Roles are granted per database or on all of them
+				Config: instanceConfig + `
+					resource scaleway_mongodb_user app {
+						instance_id = scaleway_mongodb_instance.main.id
+						name = "app"
+						password = "An0ther_v&ry_s3cret"
+
+						roles {
+							role = "read_write"
+							database_name = "app"
+						}
+
+						roles {
+							role = "read"
+							any_database = true
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isMongoDBUserPresent(tt, "scaleway_mongodb_user.app"),
+					resource.TestCheckResourceAttr("scaleway_mongodb_user.app", "roles.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_mongodb_user.app", "roles.*", map[string]string{
+						"role":          "read_write",
+						"database_name": "app",
+						"any_database":  "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_mongodb_user.app", "roles.*", map[string]string{
+						"role":          "read",
+						"database_name": "",
+						"any_database":  "true",
+					}),
+				),
+			},
+			{
+				ResourceName:            "scaleway_mongodb_user.app",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"instance_id", "password"},
+			},
+			{
+				// Removing the user deletes it from the instance
+				Config: instanceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					isMongoDBUserDestroyed(tt, "scaleway_mongodb_instance.main", "app"),
+				),
+			},
+		},
+	})
+}
+
+func getMongoDBUser(tt *acctest.TestTools, region scw.Region, instanceID string, userName string) (*mongodbSDK.User, error) {
+	res, err := mongodbSDK.NewAPI(tt.Meta.ScwClient()).ListUsers(&mongodbSDK.ListUsersRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		Name:       &userName,
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range res.Users {
+		if user.Name == userName {
+			return user, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func isMongoDBUserPresent(tt *acctest.TestTools, n string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		region, instanceID, userName, err := mongodb.ResourceUserParseID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		user, err := getMongoDBUser(tt, region, instanceID, userName)
+		if err != nil {
+			return err
+		}
+
+		if user == nil {
+			return fmt.Errorf("user %s not found on instance %s", userName, instanceID)
+		}
+
+		return nil
+	}
+}
+
+func isMongoDBUserDestroyed(tt *acctest.TestTools, instance string, userName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[instance]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", instance)
+		}
+
+		_, region, instanceID, err := mongodb.NewAPIWithRegionAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		user, err := getMongoDBUser(tt, region, instanceID, userName)
+		if err != nil {
+			return err
+		}
+
+		if user != nil {
+			return fmt.Errorf("user %s still exists on instance %s", userName, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
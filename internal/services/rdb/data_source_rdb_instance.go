@@ -72,5 +72,5 @@ func DataSourceRDBInstanceRead(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
-	return ResourceRdbInstanceRead(ctx, d, m)
+	return readRdbInstance(ctx, d, m, true)
 }
@@ -0,0 +1,181 @@
+package rdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+// ResourceDatabaseBackupRestore restores a backup into a database of an existing instance,
+// the restore is run again whenever the resource is replaced
+func ResourceDatabaseBackupRestore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceRdbDatabaseBackupRestoreCreate,
+		ReadContext:   ResourceRdbDatabaseBackupRestoreRead,
+		DeleteContext: ResourceRdbDatabaseBackupRestoreDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Default: schema.DefaultTimeout(defaultInstanceTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"backup_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "The ID of the backup to restore",
+			},
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "The ID of the instance in which the backup is restored",
+			},
+			"database_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the database in which the backup is restored, defaults to the database of the backup",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, will restore the backup again",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// Common
+			"region": regional.Schema(),
+		},
+		CustomizeDiff: cdf.LocalityCheck("backup_id", "instance_id"),
+	}
+}
+
+func ResourceRdbDatabaseBackupRestoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	rdbAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backupID := locality.ExpandID(d.Get("backup_id"))
+	instanceID := locality.ExpandID(d.Get("instance_id"))
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	backup, err := waitForRDBDatabaseBackup(ctx, rdbAPI, region, backupID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if backup.Status != rdb.DatabaseBackupStatusReady {
+		return diag.Errorf("backup %s cannot be restored, it has status %s", backup.Name, backup.Status)
+	}
+
+	backupName := backup.Name
+	databaseName := backup.DatabaseName
+	if name, ok := d.GetOk("database_name"); ok {
+		databaseName = name.(string)
+	}
+
+	_, err = waitForRDBInstance(ctx, rdbAPI, region, instanceID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = rdbAPI.RestoreDatabaseBackup(&rdb.RestoreDatabaseBackupRequest{
+		Region:           region,
+		DatabaseBackupID: backupID,
+		InstanceID:       instanceID,
+		DatabaseName:     types.ExpandStringPtr(databaseName),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(databaseBackupRestoreError(backupName, databaseName, err))
+	}
+
+	d.SetId(regional.NewIDString(region, backupID))
+	_ = d.Set("database_name", databaseName)
+
+	backup, err = waitForRDBDatabaseBackup(ctx, rdbAPI, region, backupID, timeout)
+	if err != nil {
+		return diag.FromErr(databaseBackupRestoreError(backupName, databaseName, err))
+	}
+
+	_, err = waitForRDBInstance(ctx, rdbAPI, region, instanceID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if backup.Status != rdb.DatabaseBackupStatusReady {
+		d.SetId("")
+
+		return diag.FromErr(databaseBackupRestoreError(backupName, databaseName, fmt.Errorf("backup ended with status %s", backup.Status)))
+	}
+
+	return ResourceRdbDatabaseBackupRestoreRead(ctx, d, m)
+}
+
+// ResourceRdbDatabaseBackupRestoreRead does not remove the resource when the backup is gone,
+// as it would restore the backup again on the next apply
+func ResourceRdbDatabaseBackupRestoreRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	region, backupID, err := regional.ParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("backup_id", regional.NewIDString(region, backupID))
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+// ResourceRdbDatabaseBackupRestoreDelete only removes the restore from the state, a restore cannot be undone
+func ResourceRdbDatabaseBackupRestoreDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+// databaseBackupRestoreError makes explicit that a restore fails when the target database has open connections,
+// any other failure is reported with the reason given by the API
+func databaseBackupRestoreError(backupName string, databaseName string, err error) error {
+	if isDatabaseInUseError(err) {
+		return fmt.Errorf("failed to restore backup %s into database %s, the database is in use: close all the connections to the database before restoring: %w", backupName, databaseName, err)
+	}
+
+	return fmt.Errorf("failed to restore backup %s into database %s: %w", backupName, databaseName, err)
+}
+
+// isDatabaseInUseError reports whether the API refused the restore because the database is locked or has open connections
+func isDatabaseInUseError(err error) bool {
+	resourceLockedError := &scw.ResourceLockedError{}
+	if errors.As(err, &resourceLockedError) {
+		return true
+	}
+
+	if !httperrors.Is409(err) && !httperrors.Is412(err) {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+
+	return strings.Contains(message, "in use") || strings.Contains(message, "busy") || strings.Contains(message, "connection")
+}
@@ -0,0 +1,84 @@
+package rdb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	rdbchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb/testfuncs"
+)
+
+func TestAccDatabaseBackupRestore_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	instanceName := "TestAccScalewayRdbDatabaseBackupRestore_Basic"
+	latestEngineVersion := rdbchecks.GetLatestEngineVersion(tt, postgreSQLEngineName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			rdbchecks.IsInstanceDestroyed(tt),
+			isBackupDestroyed(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseBackupRestoreConfig(instanceName, latestEngineVersion, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					isBackupPresent(tt, "scaleway_rdb_database_backup.main"),
+					isDatabasePresent(tt, "scaleway_rdb_instance.main", "scaleway_rdb_database.restored"),
+					resource.TestCheckResourceAttrPair("scaleway_rdb_database_backup_restore.main", "backup_id", "scaleway_rdb_database_backup.main", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_rdb_database_backup_restore.main", "instance_id", "scaleway_rdb_instance.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_rdb_database_backup_restore.main", "database_name", "bar"),
+				),
+			},
+			{
+				// A new trigger restores the backup again
+				Config: testAccDatabaseBackupRestoreConfig(instanceName, latestEngineVersion, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					isDatabasePresent(tt, "scaleway_rdb_instance.main", "scaleway_rdb_database.restored"),
+					resource.TestCheckResourceAttr("scaleway_rdb_database_backup_restore.main", "triggers.run", "2"),
+					resource.TestCheckResourceAttr("scaleway_rdb_database_backup_restore.main", "database_name", "bar"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatabaseBackupRestoreConfig(instanceName string, engine string, run string) string {
+	return fmt.Sprintf(`
+		resource scaleway_rdb_instance main {
+			name = "%s"
+			node_type = "db-dev-s"
+			engine = %q
+			is_ha_cluster = false
+		}
+
+		resource scaleway_rdb_database main {
+			instance_id = scaleway_rdb_instance.main.id
+			name = "foo"
+		}
+
+		resource scaleway_rdb_database restored {
+			instance_id = scaleway_rdb_instance.main.id
+			name = "bar"
+		}
+
+		resource scaleway_rdb_database_backup main {
+			instance_id = scaleway_rdb_instance.main.id
+			database_name = scaleway_rdb_database.main.name
+			name = "test_backup_restore"
+		}
+
+		resource scaleway_rdb_database_backup_restore main {
+			backup_id = scaleway_rdb_database_backup.main.id
+			instance_id = scaleway_rdb_instance.main.id
+			database_name = scaleway_rdb_database.restored.name
+
+			triggers = {
+				run = %q
+			}
+		}`, instanceName, engine, run)
+}
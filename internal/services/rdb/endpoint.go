@@ -0,0 +1,335 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceEndpoint() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceRdbEndpointCreate,
+		ReadContext:   ResourceRdbEndpointRead,
+		DeleteContext: ResourceRdbEndpointDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRdbEndpointImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Read:    schema.DefaultTimeout(defaultInstanceTimeout),
+			Delete:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Default: schema.DefaultTimeout(defaultInstanceTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"instance_id", "read_replica_id"},
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "Instance on which the endpoint is created",
+			},
+			"read_replica_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"instance_id", "read_replica_id"},
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "Read replica on which the endpoint is created",
+			},
+			"private_network": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"private_network", "load_balancer"},
+				Description:  "Private network to expose the instance on",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"private_network_id": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ForceNew:         true,
+							ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+							DiffSuppressFunc: dsf.Locality,
+							Description:      "The private network ID",
+						},
+						"service_ip": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IsCIDR,
+							Description:  "The IP with the given mask within the private subnet",
+						},
+						"enable_ipam": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							ForceNew:    true,
+							Description: "Whether the IP of the endpoint is provisioned by IPAM",
+						},
+						"zone": zonal.ComputedSchema(),
+					},
+				},
+			},
+			"load_balancer": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ForceNew:      true,
+				ExactlyOneOf:  []string{"private_network", "load_balancer"},
+				ConflictsWith: []string{"read_replica_id"},
+				Description:   "Expose the instance publicly through a load balancer",
+			},
+			// Computed
+			"ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP of the endpoint",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The port of the endpoint",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the endpoint",
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The hostname of the endpoint",
+			},
+			// Common
+			"region": regional.Schema(),
+		},
+		CustomizeDiff: cdf.LocalityCheck("instance_id", "read_replica_id", "private_network.#.private_network_id"),
+	}
+}
+
+func ResourceRdbEndpointCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	rdbAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	endpointSpec, err := expandEndpointSpec(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if readReplicaID, isReadReplica := d.GetOk("read_replica_id"); isReadReplica {
+		return resourceRdbReadReplicaEndpointCreate(ctx, d, m, rdbAPI, region, locality.ExpandID(readReplicaID), endpointSpec)
+	}
+
+	instanceID := locality.ExpandID(d.Get("instance_id"))
+
+	_, err = waitForRDBInstance(ctx, rdbAPI, region, instanceID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	endpoint, err := rdbAPI.CreateEndpoint(&rdb.CreateEndpointRequest{
+		Region:       region,
+		InstanceID:   instanceID,
+		EndpointSpec: endpointSpec,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ResourceRdbEndpointID(region, instanceID, endpoint.ID))
+
+	_, err = waitForRDBInstance(ctx, rdbAPI, region, instanceID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceRdbEndpointRead(ctx, d, m)
+}
+
+// resourceRdbReadReplicaEndpointCreate creates a private network endpoint on a read replica, the API returns
+// the read replica so the new endpoint is the one that did not exist before
+func resourceRdbReadReplicaEndpointCreate(ctx context.Context, d *schema.ResourceData, m interface{}, rdbAPI *rdb.API, region scw.Region, readReplicaID string, endpointSpec *rdb.EndpointSpec) diag.Diagnostics {
+	rr, err := waitForRDBReadReplica(ctx, rdbAPI, region, readReplicaID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	updatedRR, err := rdbAPI.CreateReadReplicaEndpoint(&rdb.CreateReadReplicaEndpointRequest{
+		Region:        region,
+		ReadReplicaID: readReplicaID,
+		EndpointSpec:  []*rdb.ReadReplicaEndpointSpec{expandReadReplicaEndpointSpec(endpointSpec)},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	created := createdEndpoints(rr.Endpoints, updatedRR.Endpoints)
+	if len(created) != 1 {
+		return diag.Errorf("could not find the endpoint created on read replica %s", readReplicaID)
+	}
+
+	d.SetId(ResourceRdbEndpointID(region, readReplicaID, created[0].ID))
+
+	_, err = waitForRDBReadReplica(ctx, rdbAPI, region, readReplicaID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceRdbEndpointRead(ctx, d, m)
+}
+
+func ResourceRdbEndpointRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	rdbAPI := newAPI(m)
+
+	region, parentID, endpointID, err := ResourceRdbEndpointParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	endpoint, err := rdbAPI.GetEndpoint(&rdb.GetEndpointRequest{
+		Region:     region,
+		EndpointID: endpointID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	privateNetwork, err := flattenEndpointPrivateNetwork(endpoint)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, isReadReplica := d.GetOk("read_replica_id"); isReadReplica {
+		_ = d.Set("read_replica_id", regional.NewIDString(region, parentID))
+	} else {
+		_ = d.Set("instance_id", regional.NewIDString(region, parentID))
+	}
+
+	_ = d.Set("private_network", privateNetwork)
+	_ = d.Set("load_balancer", endpoint.LoadBalancer != nil)
+	_ = d.Set("ip", types.FlattenIPPtr(endpoint.IP))
+	_ = d.Set("port", int(endpoint.Port))
+	_ = d.Set("name", types.FlattenStringPtr(endpoint.Name))
+	_ = d.Set("hostname", types.FlattenStringPtr(endpoint.Hostname))
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+func ResourceRdbEndpointDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	rdbAPI := newAPI(m)
+
+	region, parentID, endpointID, err := ResourceRdbEndpointParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, isReadReplica := d.GetOk("read_replica_id")
+
+	err = waitForRDBEndpointParent(ctx, rdbAPI, region, parentID, isReadReplica, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	err = rdbAPI.DeleteEndpoint(&rdb.DeleteEndpointRequest{
+		Region:     region,
+		EndpointID: endpointID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	err = waitForRDBEndpointParent(ctx, rdbAPI, region, parentID, isReadReplica, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceRdbEndpointImport finds whether the endpoint belongs to an instance or to a read replica
+func resourceRdbEndpointImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	rdbAPI := newAPI(m)
+
+	region, parentID, _, err := ResourceRdbEndpointParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = rdbAPI.GetReadReplica(&rdb.GetReadReplicaRequest{
+		Region:        region,
+		ReadReplicaID: parentID,
+	}, scw.WithContext(ctx))
+
+	switch {
+	case err == nil:
+		_ = d.Set("read_replica_id", regional.NewIDString(region, parentID))
+	case httperrors.Is404(err):
+		_ = d.Set("instance_id", regional.NewIDString(region, parentID))
+	default:
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func waitForRDBEndpointParent(ctx context.Context, rdbAPI *rdb.API, region scw.Region, parentID string, isReadReplica bool, timeout time.Duration) error {
+	if isReadReplica {
+		_, err := waitForRDBReadReplica(ctx, rdbAPI, region, parentID, timeout)
+
+		return err
+	}
+
+	_, err := waitForRDBInstance(ctx, rdbAPI, region, parentID, timeout)
+
+	return err
+}
+
+// ResourceRdbEndpointID builds the resource identifier
+// The resource identifier format is "Region/InstanceId/EndpointId", or "Region/ReadReplicaId/EndpointId" for a read replica
+func ResourceRdbEndpointID(region scw.Region, parentID string, endpointID string) (resourceID string) {
+	return fmt.Sprintf("%s/%s/%s", region, parentID, endpointID)
+}
+
+// ResourceRdbEndpointParseID extracts the instance or read replica ID and the endpoint ID from the resource identifier.
+// The resource identifier format is "Region/InstanceId/EndpointId", or "Region/ReadReplicaId/EndpointId" for a read replica
+func ResourceRdbEndpointParseID(resourceID string) (region scw.Region, parentID string, endpointID string, err error) {
+	idParts := strings.Split(resourceID, "/")
+	if len(idParts) != 3 {
+		return "", "", "", fmt.Errorf("can't parse endpoint resource id: %s", resourceID)
+	}
+
+	return scw.Region(idParts[0]), idParts[1], idParts[2], nil
+}
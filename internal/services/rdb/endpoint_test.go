@@ -0,0 +1,251 @@
+package rdb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	rdbSDK "github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb"
+	rdbchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb/testfuncs"
+	vpcchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc/testfuncs"
+)
+
+func TestAccEndpoint_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestEngineVersion := rdbchecks.GetLatestEngineVersion(tt, postgreSQLEngineName)
+
+	instanceConfig := fmt.Sprintf(`
+		resource scaleway_vpc_private_network main {
+			name = "test-rdb-endpoint-main"
+		}
+
+		resource scaleway_vpc_private_network other {
+			name = "test-rdb-endpoint-other"
+		}
+
+		resource scaleway_rdb_instance main {
+			name = "test-rdb-endpoint-basic"
+			node_type = "db-dev-s"
+			engine = %q
+			is_ha_cluster = false
+			disable_backup = true
+			user_name = "my_initial_user"
+			password = "thiZ_is_v&ry_s3cret"
+
+			private_network {
+				pn_id = scaleway_vpc_private_network.main.id
+				enable_ipam = true
+			}
+		}`, latestEngineVersion)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			rdbchecks.IsInstanceDestroyed(tt),
+			isEndpointDestroyed(tt),
+			vpcchecks.CheckPrivateNetworkDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: instanceConfig + `
+					resource scaleway_rdb_endpoint private {
+						instance_id = scaleway_rdb_instance.main.id
+						private_network {
+							private_network_id = scaleway_vpc_private_network.other.id
+							service_ip = "10.12.1.10/20"
+						}
+					}
+
+					resource scaleway_rdb_endpoint public {
+						instance_id = scaleway_rdb_instance.main.id
+						load_balancer = true
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					isEndpointPresent(tt, "scaleway_rdb_endpoint.private"),
+					isEndpointPresent(tt, "scaleway_rdb_endpoint.public"),
+					resource.TestCheckResourceAttrPair("scaleway_rdb_endpoint.private", "instance_id", "scaleway_rdb_instance.main", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_rdb_endpoint.private", "private_network.0.private_network_id", "scaleway_vpc_private_network.other", "id"),
+					resource.TestCheckResourceAttr("scaleway_rdb_endpoint.private", "private_network.0.service_ip", "10.12.1.10/20"),
+					resource.TestCheckResourceAttr("scaleway_rdb_endpoint.private", "private_network.0.enable_ipam", "false"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_endpoint.private", "port"),
+					resource.TestCheckResourceAttr("scaleway_rdb_endpoint.public", "load_balancer", "true"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_endpoint.public", "ip"),
+				),
+			},
+			{
+				// The instance only tracks the endpoint of its private_network block
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_rdb_instance.main", "private_network.#", "1"),
+					resource.TestCheckResourceAttrPair("scaleway_rdb_instance.main", "private_network.0.pn_id", "scaleway_vpc_private_network.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_rdb_instance.main", "load_balancer.#", "0"),
+				),
+			},
+			{
+				// Switching the private endpoint from a static IP to IPAM replaces it
+				Config: instanceConfig + `
+					resource scaleway_rdb_endpoint private {
+						instance_id = scaleway_rdb_instance.main.id
+						private_network {
+							private_network_id = scaleway_vpc_private_network.other.id
+							enable_ipam = true
+						}
+					}
+
+					resource scaleway_rdb_endpoint public {
+						instance_id = scaleway_rdb_instance.main.id
+						load_balancer = true
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					isEndpointPresent(tt, "scaleway_rdb_endpoint.private"),
+					resource.TestCheckResourceAttr("scaleway_rdb_endpoint.private", "private_network.0.enable_ipam", "true"),
+					resource.TestCheckResourceAttr("scaleway_rdb_instance.main", "private_network.#", "1"),
+					resource.TestCheckResourceAttrPair("scaleway_rdb_instance.main", "private_network.0.pn_id", "scaleway_vpc_private_network.main", "id"),
+				),
+			},
+			{
+				ResourceName:      "scaleway_rdb_endpoint.private",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "scaleway_rdb_endpoint.public",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Removing the endpoints keeps the one of the instance
+				Config: instanceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_rdb_instance.main", "private_network.#", "1"),
+					resource.TestCheckResourceAttrPair("scaleway_rdb_instance.main", "private_network.0.pn_id", "scaleway_vpc_private_network.main", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEndpoint_ReadReplica(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestEngineVersion := rdbchecks.GetLatestEngineVersion(tt, postgreSQLEngineName)
+
+	replicaConfig := fmt.Sprintf(`
+		resource scaleway_vpc_private_network main {
+			name = "test-rdb-endpoint-rr"
+		}
+
+		resource scaleway_rdb_instance main {
+			name = "test-rdb-endpoint-rr"
+			node_type = "db-dev-s"
+			engine = %q
+			is_ha_cluster = false
+			disable_backup = true
+			user_name = "my_initial_user"
+			password = "thiZ_is_v&ry_s3cret"
+		}
+
+		resource scaleway_rdb_read_replica main {
+			instance_id = scaleway_rdb_instance.main.id
+			direct_access {}
+		}`, latestEngineVersion)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			rdbchecks.IsInstanceDestroyed(tt),
+			isReadReplicaDestroyed(tt),
+			isEndpointDestroyed(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: replicaConfig + `
+					resource scaleway_rdb_endpoint private {
+						read_replica_id = scaleway_rdb_read_replica.main.id
+						private_network {
+							private_network_id = scaleway_vpc_private_network.main.id
+							enable_ipam = true
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					isEndpointPresent(tt, "scaleway_rdb_endpoint.private"),
+					resource.TestCheckResourceAttrPair("scaleway_rdb_endpoint.private", "read_replica_id", "scaleway_rdb_read_replica.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_rdb_endpoint.private", "instance_id", ""),
+					resource.TestCheckResourceAttr("scaleway_rdb_endpoint.private", "private_network.0.enable_ipam", "true"),
+				),
+			},
+			{
+				// The read replica does not claim the endpoint
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_rdb_read_replica.main", "private_network.#", "0"),
+					resource.TestCheckResourceAttr("scaleway_rdb_read_replica.main", "direct_access.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "scaleway_rdb_endpoint.private",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func isEndpointPresent(tt *acctest.TestTools, n string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		region, _, endpointID, err := rdb.ResourceRdbEndpointParseID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = rdbSDK.NewAPI(tt.Meta.ScwClient()).GetEndpoint(&rdbSDK.GetEndpointRequest{
+			Region:     region,
+			EndpointID: endpointID,
+		})
+
+		return err
+	}
+}
+
+func isEndpointDestroyed(tt *acctest.TestTools) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "scaleway_rdb_endpoint" {
+				continue
+			}
+
+			region, _, endpointID, err := rdb.ResourceRdbEndpointParseID(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			_, err = rdbSDK.NewAPI(tt.Meta.ScwClient()).GetEndpoint(&rdbSDK.GetEndpointRequest{
+				Region:     region,
+				EndpointID: endpointID,
+			})
+			if err == nil {
+				return fmt.Errorf("endpoint (%s) still exists", rs.Primary.ID)
+			}
+
+			if !httperrors.Is404(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
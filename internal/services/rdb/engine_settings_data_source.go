@@ -0,0 +1,166 @@
+package rdb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+)
+
+func DataSourceEngineSettings() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceRdbEngineSettingsRead,
+		Schema: map[string]*schema.Schema{
+			"engine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Database's engine version, e.g. PostgreSQL-15",
+			},
+			"settings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Settings that can be set on a running instance",
+				Elem:        engineSettingSchema(),
+			},
+			"init_settings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Settings that can only be set at database initialisation",
+				Elem:        engineSettingSchema(),
+			},
+			"region": regional.Schema(),
+		},
+	}
+}
+
+func engineSettingSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the setting",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the setting",
+			},
+			"default_value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Value used when the setting is not set",
+			},
+			"property_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the setting value: BOOLEAN, INT, FLOAT or STRING",
+			},
+			"unit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unit of the setting value",
+			},
+			"int_min": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Minimum value of INT settings",
+			},
+			"int_max": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Maximum value of INT settings",
+			},
+			"float_min": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Minimum value of FLOAT settings",
+			},
+			"float_max": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Maximum value of FLOAT settings",
+			},
+			"string_constraint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Regular expression that STRING settings must match",
+			},
+			"hot_configurable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the setting can be changed without restarting the instance",
+			},
+			"restart_required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether changing the setting restarts the instance",
+			},
+		},
+	}
+}
+
+func DataSourceRdbEngineSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	rdbAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	engineVersion, err := getEngineSettingsCatalog(ctx, rdbAPI, region, d.Get("engine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(regional.NewIDString(region, engineVersion.Name))
+	_ = d.Set("engine", engineVersion.Name)
+	_ = d.Set("settings", flattenEngineSettings(engineVersion.AvailableSettings))
+	_ = d.Set("init_settings", flattenEngineSettings(engineVersion.AvailableInitSettings))
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+func flattenEngineSettings(settings []*rdb.EngineSetting) []interface{} {
+	rawSettings := make([]interface{}, 0, len(settings))
+
+	for _, setting := range settings {
+		rawSetting := map[string]interface{}{
+			"name":             setting.Name,
+			"description":      setting.Description,
+			"default_value":    setting.DefaultValue,
+			"property_type":    setting.PropertyType.String(),
+			"hot_configurable": setting.HotConfigurable,
+			"restart_required": !setting.HotConfigurable,
+		}
+
+		if setting.Unit != nil {
+			rawSetting["unit"] = *setting.Unit
+		}
+
+		if setting.StringConstraint != nil {
+			rawSetting["string_constraint"] = *setting.StringConstraint
+		}
+
+		if setting.IntMin != nil {
+			rawSetting["int_min"] = int(*setting.IntMin)
+		}
+
+		if setting.IntMax != nil {
+			rawSetting["int_max"] = int(*setting.IntMax)
+		}
+
+		if setting.FloatMin != nil {
+			rawSetting["float_min"] = float64(*setting.FloatMin)
+		}
+
+		if setting.FloatMax != nil {
+			rawSetting["float_max"] = float64(*setting.FloatMax)
+		}
+
+		rawSettings = append(rawSettings, rawSetting)
+	}
+
+	return rawSettings
+}
@@ -3,6 +3,10 @@ package rdb
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -106,3 +111,256 @@ func getIPConfigUpdate(d *schema.ResourceData, ipFieldName string) (ipamConfig *
 
 	return ipamConfig, staticConfig
 }
+
+// engineFamily returns the engine name without its version, e.g. PostgreSQL for PostgreSQL-15
+func engineFamily(engine string) string {
+	family, _, _ := strings.Cut(engine, "-")
+
+	return family
+}
+
+// ValidateEngineUpgrade checks that an instance running currentEngine can be upgraded in place to targetEngine
+func ValidateEngineUpgrade(currentEngine string, targetEngine string, upgradableVersions []string) error {
+	for _, version := range upgradableVersions {
+		if strings.EqualFold(version, targetEngine) {
+			return nil
+		}
+	}
+
+	if len(upgradableVersions) == 0 {
+		return fmt.Errorf("engine %s cannot be upgraded to %s: no engine upgrade is available for this instance", currentEngine, targetEngine)
+	}
+
+	return fmt.Errorf("engine %s cannot be upgraded to %s: valid target versions are %s", currentEngine, targetEngine, strings.Join(upgradableVersions, ", "))
+}
+
+func findUpgradableVersion(versions []*rdb.UpgradableVersion, engine string) *rdb.UpgradableVersion {
+	for _, version := range versions {
+		if strings.EqualFold(version.Name, engine) {
+			return version
+		}
+	}
+
+	return nil
+}
+
+// switchToUpgradedInstance makes the instance created by a major upgrade the one managed by terraform,
+// the endpoints have already been moved to the new instance. The previous instance is only deleted when deletePrevious is set
+func switchToUpgradedInstance(ctx context.Context, d *schema.ResourceData, rdbAPI *rdb.API, region scw.Region, oldID string, newID string, deletePrevious bool) (string, error) {
+	d.SetId(regional.NewIDString(region, newID))
+
+	_, err := waitForRDBInstance(ctx, rdbAPI, region, newID, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return newID, err
+	}
+
+	if !deletePrevious {
+		return newID, nil
+	}
+
+	_, err = waitForRDBInstance(ctx, rdbAPI, region, oldID, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return newID, nil
+		}
+
+		return newID, err
+	}
+
+	_, err = rdbAPI.DeleteInstance(&rdb.DeleteInstanceRequest{
+		Region:     region,
+		InstanceID: oldID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return newID, fmt.Errorf("failed to delete instance %s replaced by the engine upgrade: %w", oldID, err)
+	}
+
+	return newID, nil
+}
+
+// getEngineSettingsCatalog returns the settings and init settings available for an engine version, e.g. PostgreSQL-15
+func getEngineSettingsCatalog(ctx context.Context, rdbAPI *rdb.API, region scw.Region, engine string) (*rdb.EngineVersion, error) {
+	res, err := rdbAPI.ListDatabaseEngines(&rdb.ListDatabaseEnginesRequest{
+		Region: region,
+		Name:   scw.StringPtr(engineFamily(engine)),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, databaseEngine := range res.Engines {
+		for _, version := range databaseEngine.Versions {
+			if strings.EqualFold(version.Name, engine) {
+				return version, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("engine %s is not available in region %s", engine, region)
+}
+
+func findEngineSetting(availableSettings []*rdb.EngineSetting, name string) *rdb.EngineSetting {
+	for _, setting := range availableSettings {
+		if setting.Name == name {
+			return setting
+		}
+	}
+
+	return nil
+}
+
+// validateEngineSettingValue checks a value against the type and bounds of an engine setting
+func validateEngineSettingValue(setting *rdb.EngineSetting, value string) error {
+	unit := ""
+	if setting.Unit != nil && *setting.Unit != "" {
+		unit = " " + *setting.Unit
+	}
+
+	switch setting.PropertyType {
+	case rdb.EngineSettingPropertyTypeBOOLEAN:
+		switch strings.ToLower(value) {
+		case "true", "false", "on", "off", "1", "0":
+			return nil
+		}
+
+		return fmt.Errorf("%q is not a boolean", value)
+	case rdb.EngineSettingPropertyTypeINT:
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+
+		if setting.IntMin != nil && intValue < int64(*setting.IntMin) {
+			return fmt.Errorf("%d%s is lower than the minimum %d%s", intValue, unit, *setting.IntMin, unit)
+		}
+
+		if setting.IntMax != nil && intValue > int64(*setting.IntMax) {
+			return fmt.Errorf("%d%s is greater than the maximum %d%s", intValue, unit, *setting.IntMax, unit)
+		}
+	case rdb.EngineSettingPropertyTypeFLOAT:
+		floatValue, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+
+		if setting.FloatMin != nil && floatValue < float64(*setting.FloatMin) {
+			return fmt.Errorf("%v%s is lower than the minimum %v%s", floatValue, unit, *setting.FloatMin, unit)
+		}
+
+		if setting.FloatMax != nil && floatValue > float64(*setting.FloatMax) {
+			return fmt.Errorf("%v%s is greater than the maximum %v%s", floatValue, unit, *setting.FloatMax, unit)
+		}
+	case rdb.EngineSettingPropertyTypeSTRING:
+		if setting.StringConstraint != nil && *setting.StringConstraint != "" {
+			constraint, err := regexp.Compile(*setting.StringConstraint)
+			if err != nil {
+				// The constraint is meant for the API, do not block the user if it cannot be compiled in Go
+				return nil //nolint:nilerr
+			}
+
+			if !constraint.MatchString(value) {
+				return fmt.Errorf("%q does not match %s", value, *setting.StringConstraint)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateEngineSettings checks every setting of the map against the settings available for the engine,
+// all the invalid settings are reported at once
+func ValidateEngineSettings(attribute string, settings map[string]interface{}, availableSettings []*rdb.EngineSetting) error {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	errs := []error(nil)
+
+	for _, name := range names {
+		setting := findEngineSetting(availableSettings, name)
+		if setting == nil {
+			errs = append(errs, fmt.Errorf("%s: %s is not a setting available for this engine", attribute, name))
+
+			continue
+		}
+
+		err := validateEngineSettingValue(setting, fmt.Sprint(settings[name]))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid value for %s: %w", attribute, name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// EngineSettingsRequiringRestart returns the sorted names of the settings changed between oldSettings and newSettings
+// that cannot be applied without restarting the instance
+func EngineSettingsRequiringRestart(oldSettings map[string]interface{}, newSettings map[string]interface{}, availableSettings []*rdb.EngineSetting) []string {
+	names := []string(nil)
+
+	for name, value := range newSettings {
+		if oldValue, exists := oldSettings[name]; exists && fmt.Sprint(oldValue) == fmt.Sprint(value) {
+			continue
+		}
+
+		setting := findEngineSetting(availableSettings, name)
+		if setting != nil && !setting.HotConfigurable {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ownedEndpointID returns the ID of the endpoint previously managed through an endpoint block
+// (private_network, load_balancer or direct_access)
+func ownedEndpointID(d *schema.ResourceData, block string) string {
+	oldBlock, _ := d.GetChange(block)
+
+	rawBlock, ok := oldBlock.([]interface{})
+	if !ok || len(rawBlock) == 0 || rawBlock[0] == nil {
+		return ""
+	}
+
+	return rawBlock[0].(map[string]interface{})["endpoint_id"].(string)
+}
+
+// setOwnedEndpointID records the ID of the endpoint created for an endpoint block, so that the next read
+// does not claim an endpoint created by scaleway_rdb_endpoint instead
+func setOwnedEndpointID(d *schema.ResourceData, block string, endpointID string) {
+	rawBlock, ok := d.Get(block).([]interface{})
+	if !ok || len(rawBlock) == 0 {
+		return
+	}
+
+	rawEndpoint, ok := rawBlock[0].(map[string]interface{})
+	if !ok {
+		rawEndpoint = map[string]interface{}{}
+	}
+
+	rawEndpoint["endpoint_id"] = endpointID
+	_ = d.Set(block, []interface{}{rawEndpoint})
+}
+
+// createdEndpoints returns the endpoints of after that are not in before
+func createdEndpoints(before []*rdb.Endpoint, after []*rdb.Endpoint) []*rdb.Endpoint {
+	known := make(map[string]bool, len(before))
+	for _, endpoint := range before {
+		known[endpoint.ID] = true
+	}
+
+	created := []*rdb.Endpoint(nil)
+
+	for _, endpoint := range after {
+		if !known[endpoint.ID] {
+			created = append(created, endpoint)
+		}
+	}
+
+	return created
+}
@@ -3,8 +3,11 @@ package rdb_test
 import (
 	"context"
 	"reflect"
+	"strings"
 	"testing"
 
+	rdbSDK "github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb"
 )
 
@@ -31,3 +34,93 @@ func TestPrivilegeV1SchemaUpgradeFunc(t *testing.T) {
 		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", v1Schema, actual)
 	}
 }
+
+func TestValidateEngineUpgrade(t *testing.T) {
+	upgradableVersions := []string{"PostgreSQL-15", "PostgreSQL-16"}
+
+	if err := rdb.ValidateEngineUpgrade("PostgreSQL-14", "postgresql-16", upgradableVersions); err != nil {
+		t.Errorf("expected upgrade to be valid, got %s", err)
+	}
+
+	err := rdb.ValidateEngineUpgrade("PostgreSQL-14", "PostgreSQL-17", upgradableVersions)
+	if err == nil || !strings.Contains(err.Error(), "PostgreSQL-15, PostgreSQL-16") {
+		t.Errorf("expected error naming valid targets, got %v", err)
+	}
+
+	err = rdb.ValidateEngineUpgrade("PostgreSQL-16", "PostgreSQL-17", nil)
+	if err == nil || !strings.Contains(err.Error(), "no engine upgrade is available") {
+		t.Errorf("expected error about unavailable upgrade, got %v", err)
+	}
+}
+
+func TestValidateEngineSettings(t *testing.T) {
+	availableSettings := []*rdbSDK.EngineSetting{
+		{Name: "max_connections", PropertyType: rdbSDK.EngineSettingPropertyTypeINT, IntMin: scw.Int32Ptr(50), IntMax: scw.Int32Ptr(500)},
+		{Name: "work_mem", PropertyType: rdbSDK.EngineSettingPropertyTypeINT, Unit: scw.StringPtr("MB"), IntMin: scw.Int32Ptr(1), IntMax: scw.Int32Ptr(1024), HotConfigurable: true},
+		{Name: "autovacuum_vacuum_scale_factor", PropertyType: rdbSDK.EngineSettingPropertyTypeFLOAT, FloatMin: scw.Float32Ptr(0), FloatMax: scw.Float32Ptr(1), HotConfigurable: true},
+		{Name: "log_statement", PropertyType: rdbSDK.EngineSettingPropertyTypeSTRING, StringConstraint: scw.StringPtr("^(none|ddl|mod|all)$"), HotConfigurable: true},
+		{Name: "jit", PropertyType: rdbSDK.EngineSettingPropertyTypeBOOLEAN},
+	}
+
+	err := rdb.ValidateEngineSettings("settings", map[string]interface{}{
+		"max_connections":                "200",
+		"work_mem":                       "4",
+		"autovacuum_vacuum_scale_factor": "0.2",
+		"log_statement":                  "ddl",
+		"jit":                            "off",
+	}, availableSettings)
+	if err != nil {
+		t.Errorf("expected settings to be valid, got %s", err)
+	}
+
+	err = rdb.ValidateEngineSettings("settings", map[string]interface{}{
+		"max_connections":                "1000",
+		"work_mem":                       "4MB",
+		"autovacuum_vacuum_scale_factor": "2",
+		"log_statement":                  "everything",
+		"jit":                            "maybe",
+		"max_conections":                 "200",
+	}, availableSettings)
+	if err == nil {
+		t.Fatal("expected settings to be invalid")
+	}
+
+	for _, expected := range []string{
+		"max_connections: 1000 is greater than the maximum 500",
+		`work_mem: "4MB" is not an integer`,
+		"autovacuum_vacuum_scale_factor: 2 is greater than the maximum 1",
+		`log_statement: "everything" does not match`,
+		`jit: "maybe" is not a boolean`,
+		"max_conections is not a setting available for this engine",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got %s", expected, err)
+		}
+	}
+}
+
+func TestEngineSettingsRequiringRestart(t *testing.T) {
+	availableSettings := []*rdbSDK.EngineSetting{
+		{Name: "max_connections"},
+		{Name: "shared_buffers"},
+		{Name: "work_mem", HotConfigurable: true},
+	}
+
+	restartSettings := rdb.EngineSettingsRequiringRestart(
+		map[string]interface{}{"max_connections": "200", "shared_buffers": "128", "work_mem": "4"},
+		map[string]interface{}{"max_connections": "300", "shared_buffers": "128", "work_mem": "8"},
+		availableSettings,
+	)
+	if !reflect.DeepEqual(restartSettings, []string{"max_connections"}) {
+		t.Errorf("expected only max_connections to require a restart, got %v", restartSettings)
+	}
+
+	restartSettings = rdb.EngineSettingsRequiringRestart(
+		map[string]interface{}{},
+		map[string]interface{}{"shared_buffers": "256", "max_connections": "300"},
+		availableSettings,
+	)
+	if !reflect.DeepEqual(restartSettings, []string{"max_connections", "shared_buffers"}) {
+		t.Errorf("expected new settings to require a restart, got %v", restartSettings)
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
@@ -15,8 +18,10 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
@@ -36,7 +41,7 @@ func ResourceInstance() *schema.Resource {
 			Default: schema.DefaultTimeout(defaultInstanceTimeout),
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceRdbInstanceImport,
 		},
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
@@ -56,13 +61,18 @@ func ResourceInstance() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				ForceNew:         true,
 				Description:      "Database's engine version id",
 				DiffSuppressFunc: dsf.IgnoreCase,
 				ConflictsWith: []string{
 					"snapshot_id",
 				},
 			},
+			"delete_previous_instance_on_upgrade": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the previous Database Instance once a major engine upgrade moved its endpoints to the new one",
+			},
 			"snapshot_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -322,15 +332,163 @@ func ResourceInstance() *schema.Resource {
 				Optional:    true,
 				Description: "Enable or disable encryption at rest for the database instance",
 			},
+			"upgradable_versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of available engine versions for upgrade",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "UUID of the upgradable version",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the engine version, to be used in the engine field",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version of the engine",
+						},
+						"minor_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Minor version of the engine",
+						},
+					},
+				},
+			},
 			// Common
 			"region":          regional.Schema(),
 			"organization_id": account.OrganizationIDSchema(),
 			"project_id":      account.ProjectIDSchema(),
 		},
-		CustomizeDiff: cdf.LocalityCheck("private_network.#.pn_id"),
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("private_network.#.pn_id"),
+			customizeDiffInstanceEngineUpgrade,
+			customizeDiffInstanceSettings,
+		),
 	}
 }
 
+// customizeDiffInstanceEngineUpgrade allows engine changes in place when the new engine is one of the upgradable versions,
+// switching to another engine family still recreates the instance
+func customizeDiffInstanceEngineUpgrade(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("engine") || !diff.NewValueKnown("engine") {
+		return nil
+	}
+
+	oldEngine, newEngine := diff.GetChange("engine")
+	if oldEngine.(string) == "" || newEngine.(string) == "" {
+		return nil
+	}
+
+	if !strings.EqualFold(engineFamily(oldEngine.(string)), engineFamily(newEngine.(string))) {
+		return diff.ForceNew("engine")
+	}
+
+	upgradableVersions := []string(nil)
+	for _, rawVersion := range diff.Get("upgradable_versions").([]interface{}) {
+		upgradableVersions = append(upgradableVersions, rawVersion.(map[string]interface{})["name"].(string))
+	}
+
+	err := ValidateEngineUpgrade(oldEngine.(string), newEngine.(string), upgradableVersions)
+	if err != nil {
+		return err
+	}
+
+	// The upgrade creates a new instance with new endpoints and certificate
+	for _, computedField := range []string{"upgradable_versions", "certificate", "endpoint_ip", "endpoint_port", "load_balancer"} {
+		err = diff.SetNewComputed(computedField)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// customizeDiffInstanceSettings validates settings and init_settings against the settings available for the engine,
+// so that invalid values fail at plan time instead of after the instance is created
+func customizeDiffInstanceSettings(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.HasChanges("settings", "init_settings", "engine") ||
+		!diff.NewValueKnown("engine") || !diff.NewValueKnown("settings") || !diff.NewValueKnown("init_settings") {
+		return nil
+	}
+
+	engine := diff.Get("engine").(string)
+	settings := diff.Get("settings").(map[string]interface{})
+	initSettings := diff.Get("init_settings").(map[string]interface{})
+
+	if engine == "" || (len(settings) == 0 && len(initSettings) == 0) {
+		return nil
+	}
+
+	region, err := meta.ExtractRegion(diff, m)
+	if err != nil {
+		return err
+	}
+
+	engineVersion, err := getEngineSettingsCatalog(ctx, newAPI(m), region, engine)
+	if err != nil {
+		// The catalog is only used for early validation, the API still validates the settings on apply
+		tflog.Warn(ctx, fmt.Sprintf("could not fetch the settings available for engine %s, skipping validation: %s", engine, err))
+
+		return nil
+	}
+
+	err = errors.Join(
+		ValidateEngineSettings("settings", settings, engineVersion.AvailableSettings),
+		ValidateEngineSettings("init_settings", initSettings, engineVersion.AvailableInitSettings),
+	)
+	if err != nil {
+		return err
+	}
+
+	if diff.Id() != "" && diff.HasChange("settings") {
+		oldSettings, _ := diff.GetChange("settings")
+
+		restartSettings := EngineSettingsRequiringRestart(oldSettings.(map[string]interface{}), settings, engineVersion.AvailableSettings)
+		if len(restartSettings) > 0 {
+			// A CustomizeDiff cannot return warnings and a ValidateRawResourceConfigFunc has neither the prior settings
+			// nor an API client to fetch the catalog, so the restart is logged here and reported as a warning diagnostic on apply
+			tflog.Warn(ctx, fmt.Sprintf("changing settings %s will restart the instance", strings.Join(restartSettings, ", ")))
+		}
+	}
+
+	return nil
+}
+
+// resourceRdbInstanceImport claims the first private network and load balancer endpoints of the instance,
+// the other ones are expected to be imported as scaleway_rdb_endpoint
+func resourceRdbInstanceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	rdbAPI, region, ID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := rdbAPI.GetInstance(&rdb.GetInstanceRequest{
+		Region:     region,
+		InstanceID: ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if pnI, pnExist := flattenPrivateNetwork(res.Endpoints, "", ""); pnExist {
+		_ = d.Set("private_network", pnI)
+	}
+
+	if lbI, lbExists := flattenLoadBalancer(res.Endpoints, ""); lbExists {
+		_ = d.Set("load_balancer", lbI)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 //gocyclo:ignore
 func ResourceRdbInstanceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	rdbAPI, region, err := newAPIWithRegion(d, m)
@@ -526,6 +684,12 @@ func ResourceRdbInstanceCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func ResourceRdbInstanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return readRdbInstance(ctx, d, m, false)
+}
+
+// readRdbInstance reads the instance into d, the first private network endpoint is only flattened when anyPrivateNetwork
+// is set, otherwise only the endpoint declared in the private_network block is
+func readRdbInstance(ctx context.Context, d *schema.ResourceData, m interface{}, anyPrivateNetwork bool) diag.Diagnostics {
 	rdbAPI, region, ID, err := NewAPIWithRegionAndID(m, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -593,6 +757,8 @@ func ResourceRdbInstanceRead(ctx context.Context, d *schema.ResourceData, m inte
 		_ = d.Set("encryption_at_rest", res.Encryption.Enabled)
 	}
 
+	_ = d.Set("upgradable_versions", flattenUpgradableVersions(res.UpgradableVersion))
+
 	// set user and password
 	if user, ok := d.GetOk("user_name"); ok {
 		_ = d.Set("user_name", user.(string))
@@ -639,12 +805,21 @@ func ResourceRdbInstanceRead(ctx context.Context, d *schema.ResourceData, m inte
 	// set logs policy
 	_ = d.Set("logs_policy", flattenInstanceLogsPolicy(res.LogsPolicy))
 
-	// set endpoints
-	if pnI, pnExist := flattenPrivateNetwork(res.Endpoints); pnExist {
-		_ = d.Set("private_network", pnI)
+	// set endpoints, only the private network endpoint declared in the private_network block is tracked
+	pnEndpointID := d.Get("private_network.0.endpoint_id").(string)
+	pnID := locality.ExpandID(d.Get("private_network.0.pn_id"))
+
+	if anyPrivateNetwork || pnEndpointID != "" || pnID != "" {
+		if pnI, pnExist := flattenPrivateNetwork(res.Endpoints, pnEndpointID, pnID); pnExist {
+			_ = d.Set("private_network", pnI)
+		}
 	}
 
-	if lbI, lbExists := flattenLoadBalancer(res.Endpoints); lbExists {
+	// only the load balancer endpoint declared in the load_balancer block is tracked
+	lbEndpointID := d.Get("load_balancer.0.endpoint_id").(string)
+
+	if lbEndpointID != "" || len(d.Get("load_balancer").([]interface{})) > 0 {
+		lbI, _ := flattenLoadBalancer(res.Endpoints, lbEndpointID)
 		_ = d.Set("load_balancer", lbI)
 	}
 
@@ -658,6 +833,8 @@ func ResourceRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	diags := diag.Diagnostics{}
+
 	////////////////////
 	// Upgrade instance
 	////////////////////
@@ -785,6 +962,24 @@ func ResourceRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, m in
 			})
 	}
 
+	// Major engine upgrade, done last as it moves the instance to a new one
+	if d.HasChange("engine") {
+		upgradableVersion := findUpgradableVersion(rdbInstance.UpgradableVersion, d.Get("engine").(string))
+		if upgradableVersion == nil {
+			return diag.FromErr(ValidateEngineUpgrade(rdbInstance.Engine, d.Get("engine").(string), flattenUpgradableVersionNames(rdbInstance.UpgradableVersion)))
+		}
+
+		upgradeInstanceRequests = append(upgradeInstanceRequests,
+			rdb.UpgradeInstanceRequest{
+				Region:     region,
+				InstanceID: ID,
+				MajorUpgradeWorkflow: &rdb.UpgradeInstanceRequestMajorUpgradeWorkflow{
+					UpgradableVersionID: upgradableVersion.ID,
+					WithEndpoints:       true,
+				},
+			})
+	}
+
 	// Carry out the upgrades
 	for i := range upgradeInstanceRequests {
 		_, err = waitForRDBInstance(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutUpdate))
@@ -792,11 +987,30 @@ func ResourceRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, m in
 			return diag.FromErr(err)
 		}
 
-		_, err = rdbAPI.UpgradeInstance(&upgradeInstanceRequests[i], scw.WithContext(ctx))
+		upgradedInstance, err := rdbAPI.UpgradeInstance(&upgradeInstanceRequests[i], scw.WithContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
+		if upgradeInstanceRequests[i].MajorUpgradeWorkflow != nil && upgradedInstance.ID != ID {
+			previousID := ID
+
+			ID, err = switchToUpgradedInstance(ctx, d, rdbAPI, region, ID, upgradedInstance.ID, d.Get("delete_previous_instance_on_upgrade").(bool))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			if !d.Get("delete_previous_instance_on_upgrade").(bool) {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Previous Database Instance kept",
+					Detail: fmt.Sprintf("The engine upgrade created the Database Instance %s and moved the endpoints to it. "+
+						"The previous Database Instance %s is kept and still billed, delete it once the upgrade is validated, "+
+						"or set delete_previous_instance_on_upgrade to delete it during the upgrade.", upgradedInstance.ID, previousID),
+				})
+			}
+		}
+
 		_, err = waitForRDBInstance(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutUpdate))
 		if err != nil && !httperrors.Is404(err) {
 			return diag.FromErr(err)
@@ -861,6 +1075,8 @@ func ResourceRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, m in
 			return diag.FromErr(err)
 		}
 
+		diags = append(diags, instanceSettingsRestartWarning(ctx, d, rdbAPI, region)...)
+
 		_, err := rdbAPI.SetInstanceSettings(&rdb.SetInstanceSettingsRequest{
 			InstanceID: ID,
 			Region:     region,
@@ -903,16 +1119,18 @@ func ResourceRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, m in
 			return diag.FromErr(err)
 		}
 
-		// delete old endpoint
+		// delete old endpoint, the ones created by scaleway_rdb_endpoint are kept
+		oldPNEndpointID := ownedEndpointID(d, "private_network")
+
 		for _, e := range res.Endpoints {
-			if e.PrivateNetwork != nil {
+			if e.PrivateNetwork != nil && e.ID == oldPNEndpointID {
 				err := rdbAPI.DeleteEndpoint(
 					&rdb.DeleteEndpointRequest{
 						EndpointID: e.ID, Region: region,
 					},
 					scw.WithContext(ctx))
-				if err != nil {
-					diag.FromErr(err)
+				if err != nil && !httperrors.Is404(err) {
+					return diag.FromErr(err)
 				}
 			}
 		}
@@ -926,22 +1144,24 @@ func ResourceRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, m in
 		if pnExist {
 			ipamConfig, staticConfig := getIPConfigUpdate(d, "ip_net")
 
-			privateEndpoints, diags := expandPrivateNetwork(pn, pnExist, ipamConfig, staticConfig)
-			if diags.HasError() {
-				return diags
+			privateEndpoints, pnDiags := expandPrivateNetwork(pn, pnExist, ipamConfig, staticConfig)
+			if pnDiags.HasError() {
+				return pnDiags
 			}
 
-			for _, warning := range diags {
+			for _, warning := range pnDiags {
 				tflog.Warn(ctx, warning.Detail)
 			}
 
 			for _, e := range privateEndpoints {
-				_, err := rdbAPI.CreateEndpoint(
+				endpoint, err := rdbAPI.CreateEndpoint(
 					&rdb.CreateEndpointRequest{Region: region, InstanceID: ID, EndpointSpec: e},
 					scw.WithContext(ctx))
 				if err != nil {
 					return diag.FromErr(err)
 				}
+
+				setOwnedEndpointID(d, "private_network", endpoint.ID)
 			}
 		}
 	}
@@ -953,14 +1173,16 @@ func ResourceRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, m in
 			return diag.FromErr(err)
 		}
 		// delete old endpoint
+		oldLBEndpointID := ownedEndpointID(d, "load_balancer")
+
 		for _, e := range res.Endpoints {
-			if e.LoadBalancer != nil {
+			if e.LoadBalancer != nil && e.ID == oldLBEndpointID {
 				err := rdbAPI.DeleteEndpoint(&rdb.DeleteEndpointRequest{
 					EndpointID: e.ID,
 					Region:     region,
 				}, scw.WithContext(ctx))
-				if err != nil {
-					diag.FromErr(err)
+				if err != nil && !httperrors.Is404(err) {
+					return diag.FromErr(err)
 				}
 			}
 		}
@@ -971,7 +1193,7 @@ func ResourceRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, m in
 		}
 		// set new endpoint
 		if _, lbExists := d.GetOk("load_balancer"); lbExists {
-			_, err := rdbAPI.CreateEndpoint(&rdb.CreateEndpointRequest{
+			endpoint, err := rdbAPI.CreateEndpoint(&rdb.CreateEndpointRequest{
 				Region:       region,
 				InstanceID:   ID,
 				EndpointSpec: expandLoadBalancer(),
@@ -979,10 +1201,39 @@ func ResourceRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, m in
 			if err != nil {
 				return diag.FromErr(err)
 			}
+
+			setOwnedEndpointID(d, "load_balancer", endpoint.ID)
 		}
 	}
 
-	return ResourceRdbInstanceRead(ctx, d, m)
+	return append(diags, ResourceRdbInstanceRead(ctx, d, m)...)
+}
+
+// instanceSettingsRestartWarning warns about the settings of the update that are not hot configurable
+func instanceSettingsRestartWarning(ctx context.Context, d *schema.ResourceData, rdbAPI *rdb.API, region scw.Region) diag.Diagnostics {
+	engine := d.Get("engine").(string)
+
+	engineVersion, err := getEngineSettingsCatalog(ctx, rdbAPI, region, engine)
+	if err != nil {
+		// The warning is informative, the settings have already been applied
+		tflog.Warn(ctx, fmt.Sprintf("could not fetch the settings available for engine %s, cannot tell which settings restarted the instance: %s", engine, err))
+
+		return nil
+	}
+
+	oldSettings, newSettings := d.GetChange("settings")
+
+	restartSettings := EngineSettingsRequiringRestart(oldSettings.(map[string]interface{}), newSettings.(map[string]interface{}), engineVersion.AvailableSettings)
+	if len(restartSettings) == 0 {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       "instance restarted to apply settings",
+		Detail:        fmt.Sprintf("Settings %s are not hot configurable, the instance is restarted to apply them.", strings.Join(restartSettings, ", ")),
+		AttributePath: cty.GetAttrPath("settings"),
+	}}
 }
 
 func ResourceRdbInstanceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -0,0 +1,75 @@
+package rdb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+// DataSourceInstanceLogs lists the logs already prepared for a Database Instance, the logs are prepared
+// by the scaleway_rdb_instance_logs_export resource so that reading the data source has no side effect
+func DataSourceInstanceLogs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceRdbInstanceLogsRead,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				Description:      "The ID of the Database Instance to list the logs of",
+			},
+			"logs":   instanceLogsSchema("The logs prepared for the Database Instance"),
+			"region": regional.Schema(),
+		},
+	}
+}
+
+func DataSourceRdbInstanceLogsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	rdbAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceID := locality.ExpandID(d.Get("instance_id"))
+
+	res, err := rdbAPI.ListInstanceLogs(&rdb.ListInstanceLogsRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		OrderBy:    rdb.ListInstanceLogsRequestOrderByCreatedAtDesc,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	httpClient := meta.ExtractHTTPClient(m)
+	logs := []interface{}(nil)
+
+	for _, instanceLog := range res.InstanceLogs {
+		rawLog := flattenInstanceLog(instanceLog)
+
+		// Only the logs that are ready can be downloaded
+		if instanceLog.Status == rdb.InstanceLogStatusReady && instanceLog.DownloadURL != nil {
+			size, err := getInstanceLogSize(ctx, httpClient, *instanceLog.DownloadURL)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			rawLog["size"] = size
+		}
+
+		logs = append(logs, rawLog)
+	}
+
+	d.SetId(regional.NewIDString(region, instanceID))
+	_ = d.Set("logs", logs)
+	_ = d.Set("region", region)
+
+	return nil
+}
@@ -0,0 +1,390 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/object"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+// ResourceInstanceLogsExport prepares the logs of a Database Instance for a time window and optionally copies them
+// into a bucket, the export is run again whenever the resource is replaced
+func ResourceInstanceLogsExport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceRdbInstanceLogsExportCreate,
+		ReadContext:   ResourceRdbInstanceLogsExportRead,
+		DeleteContext: ResourceRdbInstanceLogsExportDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Default: schema.DefaultTimeout(defaultInstanceTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "The ID of the Database Instance to export the logs of",
+			},
+			"start_date": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsDate(),
+				Description:      "Start of the time window of the logs (Format ISO 8601)",
+			},
+			"end_date": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsDate(),
+				Description:      "End of the time window of the logs (Format ISO 8601)",
+			},
+			"bucket": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name or ID of the bucket in which the logs are copied",
+			},
+			"bucket_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"bucket"},
+				Description:  "Prefix of the keys of the logs copied in the bucket, defaults to rdb-logs/<instance_id>/",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, will export the logs again",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"logs":   instanceLogsSchema("The exported logs"),
+			"region": regional.Schema(),
+		},
+	}
+}
+
+func ResourceRdbInstanceLogsExportCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	rdbAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceID := locality.ExpandID(d.Get("instance_id"))
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	var s3Client *s3.Client
+
+	bucket, copyToBucket := d.GetOk("bucket")
+	bucketName := bucket.(string)
+
+	if copyToBucket {
+		// The bucket can be given by name or by the ID of a scaleway_object_bucket
+		bucketRegion := region
+		if parsedRegion, parsedName, err := regional.ParseID(bucketName); err == nil {
+			bucketRegion, bucketName = parsedRegion, parsedName
+		}
+
+		s3Client, err = object.NewS3ClientFromMeta(ctx, m.(*meta.Meta), bucketRegion.String())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	res, err := rdbAPI.PrepareInstanceLogs(&rdb.PrepareInstanceLogsRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		StartDate:  types.ExpandTimePtr(d.Get("start_date")),
+		EndDate:    types.ExpandTimePtr(d.Get("end_date")),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	prefix := d.Get("bucket_prefix").(string)
+	if prefix == "" {
+		prefix = "rdb-logs/" + instanceID + "/"
+	}
+
+	httpClient := meta.ExtractHTTPClient(m)
+	logs := []interface{}(nil)
+
+	for _, instanceLog := range res.InstanceLogs {
+		instanceLog, err = waitForRDBInstanceLog(ctx, rdbAPI, region, instanceLog.ID, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if instanceLog.Status != rdb.InstanceLogStatusReady || instanceLog.DownloadURL == nil {
+			return diag.Errorf("log %s of node %s could not be prepared, it has status %s", instanceLog.ID, instanceLog.NodeName, instanceLog.Status)
+		}
+
+		rawLog := flattenInstanceLog(instanceLog)
+
+		if copyToBucket {
+			key := prefix + instanceLogObjectName(instanceLog)
+
+			size, err := copyInstanceLogToBucket(ctx, httpClient, s3Client, *instanceLog.DownloadURL, bucketName, key)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			rawLog["size"] = size
+			rawLog["object_key"] = key
+		} else {
+			size, err := getInstanceLogSize(ctx, httpClient, *instanceLog.DownloadURL)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			rawLog["size"] = size
+		}
+
+		logs = append(logs, rawLog)
+	}
+
+	d.SetId(regional.NewIDString(region, instanceID))
+	_ = d.Set("logs", logs)
+
+	return ResourceRdbInstanceLogsExportRead(ctx, d, m)
+}
+
+// ResourceRdbInstanceLogsExportRead refreshes the logs that still exist. An expired log is kept in the state
+// with its last known attributes, as removing the resource would export the logs again on the next apply.
+func ResourceRdbInstanceLogsExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	rdbAPI, region, _, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logs := d.Get("logs").([]interface{})
+
+	for i, rawLog := range logs {
+		rawLog := rawLog.(map[string]interface{})
+
+		instanceLog, err := rdbAPI.GetInstanceLog(&rdb.GetInstanceLogRequest{
+			Region:        region,
+			InstanceLogID: locality.ExpandID(rawLog["id"]),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if httperrors.Is404(err) {
+				continue
+			}
+
+			return diag.FromErr(err)
+		}
+
+		refreshedLog := flattenInstanceLog(instanceLog)
+		refreshedLog["size"] = rawLog["size"]
+		refreshedLog["object_key"] = rawLog["object_key"]
+		logs[i] = refreshedLog
+	}
+
+	_ = d.Set("logs", logs)
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+// ResourceRdbInstanceLogsExportDelete only removes the export from the state, the objects copied in the bucket are kept
+func ResourceRdbInstanceLogsExportDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+func instanceLogsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the log",
+				},
+				"node_name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the node the log comes from",
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The status of the log",
+				},
+				"download_url": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Sensitive:   true,
+					Description: "The presigned URL to download the log",
+				},
+				"size": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The size of the log (in bytes)",
+				},
+				"object_key": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The key of the log in the bucket, if copied",
+				},
+				"expires_at": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Expiration date of the download URL (Format ISO 8601)",
+				},
+				"created_at": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Creation date (Format ISO 8601)",
+				},
+			},
+		},
+	}
+}
+
+func flattenInstanceLog(instanceLog *rdb.InstanceLog) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           regional.NewIDString(instanceLog.Region, instanceLog.ID),
+		"node_name":    instanceLog.NodeName,
+		"status":       instanceLog.Status.String(),
+		"download_url": types.FlattenStringPtr(instanceLog.DownloadURL),
+		"expires_at":   types.FlattenTime(instanceLog.ExpiresAt),
+		"created_at":   types.FlattenTime(instanceLog.CreatedAt),
+	}
+}
+
+// instanceLogObjectName keeps the file name of the presigned URL, prefixed by the node name to avoid collisions
+func instanceLogObjectName(instanceLog *rdb.InstanceLog) string {
+	fileName := instanceLog.ID + ".log"
+
+	if instanceLog.DownloadURL != nil {
+		urlPath, _, _ := strings.Cut(*instanceLog.DownloadURL, "?")
+		if base := path.Base(urlPath); base != "." && base != "/" {
+			fileName = base
+		}
+	}
+
+	return instanceLog.NodeName + "/" + fileName
+}
+
+// getInstanceLogSize reads the size of a log from the Content-Range of a one byte request,
+// a HEAD request cannot be used as the URL is only signed for GET
+func getInstanceLogSize(ctx context.Context, httpClient *http.Client, downloadURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Range", "bytes=0-0")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		_, total, found := strings.Cut(resp.Header.Get("Content-Range"), "/")
+		if !found || total == "*" {
+			return 0, fmt.Errorf("invalid Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+
+		return strconv.Atoi(total)
+	case http.StatusOK:
+		// The range was ignored, the length is unknown when the body is chunked
+		if resp.ContentLength >= 0 {
+			return int(resp.ContentLength), nil
+		}
+
+		size, err := io.Copy(io.Discard, resp.Body)
+
+		return int(size), err
+	default:
+		return 0, fmt.Errorf("failed to get log size: %s", resp.Status)
+	}
+}
+
+// copyInstanceLogToBucket streams a log into the bucket and returns its size. A log of unknown length
+// is first written to a temporary file, as an object cannot be uploaded without its length.
+func copyInstanceLogToBucket(ctx context.Context, httpClient *http.Client, s3Client *s3.Client, downloadURL string, bucket string, key string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to download log: %s", resp.Status)
+	}
+
+	body := io.Reader(resp.Body)
+	size := resp.ContentLength
+
+	if size < 0 {
+		tmpFile, err := os.CreateTemp("", "scaleway-rdb-log-*")
+		if err != nil {
+			return 0, err
+		}
+		defer os.Remove(tmpFile.Name())
+		defer tmpFile.Close()
+
+		size, err = io.Copy(tmpFile, resp.Body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to download log: %w", err)
+		}
+
+		_, err = tmpFile.Seek(0, io.SeekStart)
+		if err != nil {
+			return 0, err
+		}
+
+		body = tmpFile
+	}
+
+	_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(bucket),
+		Key:           aws.String(key),
+		Body:          body,
+		ContentLength: aws.Int64(size),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to copy log to bucket %s: %w", bucket, err)
+	}
+
+	return int(size), nil
+}
@@ -0,0 +1,74 @@
+package rdb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	rdbchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb/testfuncs"
+)
+
+func TestAccInstanceLogsExport_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestEngineVersion := rdbchecks.GetLatestEngineVersion(tt, postgreSQLEngineName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      rdbchecks.IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceLogsExportConfig(latestEngineVersion, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_rdb_instance_logs_export.main", "instance_id", "scaleway_rdb_instance.main", "id"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_instance_logs_export.main", "logs.0.id"),
+					resource.TestCheckResourceAttr("scaleway_rdb_instance_logs_export.main", "logs.0.status", "ready"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_instance_logs_export.main", "logs.0.download_url"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_instance_logs_export.main", "logs.0.size"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_instance_logs_export.main", "logs.0.expires_at"),
+				),
+			},
+			{
+				// Reading the data source lists the exported logs without preparing new ones
+				Config: testAccInstanceLogsExportConfig(latestEngineVersion, "1") + `
+					data scaleway_rdb_instance_logs main {
+						instance_id = scaleway_rdb_instance.main.id
+						depends_on = [scaleway_rdb_instance_logs_export.main]
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.scaleway_rdb_instance_logs.main", "logs.#", "scaleway_rdb_instance_logs_export.main", "logs.#"),
+					resource.TestCheckResourceAttrPair("data.scaleway_rdb_instance_logs.main", "logs.0.id", "scaleway_rdb_instance_logs_export.main", "logs.0.id"),
+				),
+			},
+			{
+				// A new trigger exports the logs again
+				Config: testAccInstanceLogsExportConfig(latestEngineVersion, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_rdb_instance_logs_export.main", "triggers.run", "2"),
+					resource.TestCheckResourceAttr("scaleway_rdb_instance_logs_export.main", "logs.0.status", "ready"),
+				),
+			},
+		},
+	})
+}
+
+func testAccInstanceLogsExportConfig(engine string, run string) string {
+	return fmt.Sprintf(`
+		resource scaleway_rdb_instance main {
+			name = "test-rdb-instance-logs-export"
+			node_type = "db-dev-s"
+			engine = %q
+			is_ha_cluster = false
+		}
+
+		resource scaleway_rdb_instance_logs_export main {
+			instance_id = scaleway_rdb_instance.main.id
+
+			triggers = {
+				run = %q
+			}
+		}`, engine, run)
+}
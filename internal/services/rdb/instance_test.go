@@ -1,13 +1,16 @@
 package rdb_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	rdbSDK "github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb"
 	rdbchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb/testfuncs"
 	vpcchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc/testfuncs"
@@ -1135,6 +1138,72 @@ func TestAccInstance_ChangeNodeType(t *testing.T) {
 	})
 }
 
+func TestAccInstance_EngineUpgrade(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	previousInstance := &rdbSDK.Instance{}
+	previousLBIP, previousLBPort := "", ""
+
+	config := `
+		resource scaleway_rdb_instance main {
+			name = "test-rdb-instance-engine-upgrade"
+			node_type = "db-play2-pico"
+			engine = %q
+			is_ha_cluster = false
+			disable_backup = true
+			user_name = "my_initial_user"
+			password = "thiZ_is_v&ry_s3cret"
+			tags = [ "terraform-test", "scaleway_rdb_instance", "engine-upgrade" ]
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      rdbchecks.IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, "PostgreSQL-15"),
+				Check: resource.ComposeTestCheckFunc(
+					isInstancePresent(tt, "scaleway_rdb_instance.main"),
+					resource.TestCheckResourceAttr("scaleway_rdb_instance.main", "engine", "PostgreSQL-15"),
+					resource.TestCheckResourceAttrWith("scaleway_rdb_instance.main", "load_balancer.0.ip", func(ip string) error {
+						previousLBIP = ip
+
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("scaleway_rdb_instance.main", "load_balancer.0.port", func(port string) error {
+						previousLBPort = port
+
+						return nil
+					}),
+					createInstanceDatabase(tt, "scaleway_rdb_instance.main", "engine_upgrade_data"),
+					getInstance(tt, "scaleway_rdb_instance.main", previousInstance),
+				),
+			},
+			{
+				Config: fmt.Sprintf(config, "PostgreSQL-16"),
+				Check: resource.ComposeTestCheckFunc(
+					isInstancePresent(tt, "scaleway_rdb_instance.main"),
+					resource.TestCheckResourceAttr("scaleway_rdb_instance.main", "engine", "PostgreSQL-16"),
+					resource.TestCheckResourceAttrWith("scaleway_rdb_instance.main", "id", func(id string) error {
+						if locality.ExpandID(id) == previousInstance.ID {
+							return errors.New("engine upgrade should have moved to a new instance")
+						}
+
+						return nil
+					}),
+					resource.TestCheckResourceAttrPtr("scaleway_rdb_instance.main", "load_balancer.0.ip", &previousLBIP),
+					resource.TestCheckResourceAttrPtr("scaleway_rdb_instance.main", "load_balancer.0.port", &previousLBPort),
+					isInstanceDatabasePresent(tt, "scaleway_rdb_instance.main", "engine_upgrade_data"),
+					isPreviousInstanceKept(tt, previousInstance),
+				),
+			},
+		},
+	})
+}
+
 func TestAccInstance_Endpoints(t *testing.T) {
 	tt := acctest.NewTestTools(t)
 	defer tt.Cleanup()
@@ -1503,3 +1572,105 @@ func isInstancePresent(tt *acctest.TestTools, n string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func getInstance(tt *acctest.TestTools, n string, instance *rdbSDK.Instance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		rdbAPI, region, ID, err := rdb.NewAPIWithRegionAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err := rdbAPI.GetInstance(&rdbSDK.GetInstanceRequest{
+			InstanceID: ID,
+			Region:     region,
+		})
+		if err != nil {
+			return err
+		}
+
+		*instance = *res
+
+		return nil
+	}
+}
+
+func createInstanceDatabase(tt *acctest.TestTools, n string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		rdbAPI, region, ID, err := rdb.NewAPIWithRegionAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = rdbAPI.CreateDatabase(&rdbSDK.CreateDatabaseRequest{
+			Region:     region,
+			InstanceID: ID,
+			Name:       name,
+		})
+
+		return err
+	}
+}
+
+func isInstanceDatabasePresent(tt *acctest.TestTools, n string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		rdbAPI, region, ID, err := rdb.NewAPIWithRegionAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err := rdbAPI.ListDatabases(&rdbSDK.ListDatabasesRequest{
+			Region:     region,
+			InstanceID: ID,
+			Name:       &name,
+		}, scw.WithAllPages())
+		if err != nil {
+			return err
+		}
+
+		for _, database := range res.Databases {
+			if database.Name == name {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("database %s not found on instance %s", name, ID)
+	}
+}
+
+// isPreviousInstanceKept checks the instance replaced by an engine upgrade was kept, then deletes it
+// as it is not tracked in the state anymore
+func isPreviousInstanceKept(tt *acctest.TestTools, previousInstance *rdbSDK.Instance) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		rdbAPI := rdbSDK.NewAPI(tt.Meta.ScwClient())
+
+		_, err := rdbAPI.GetInstance(&rdbSDK.GetInstanceRequest{
+			Region:     previousInstance.Region,
+			InstanceID: previousInstance.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("previous instance should have been kept: %w", err)
+		}
+
+		_, err = rdbAPI.DeleteInstance(&rdbSDK.DeleteInstanceRequest{
+			Region:     previousInstance.Region,
+			InstanceID: previousInstance.ID,
+		})
+
+		return err
+	}
+}
@@ -33,7 +33,7 @@ func ResourceReadReplica() *schema.Resource {
 			Default: schema.DefaultTimeout(defaultInstanceTimeout),
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceRdbReadReplicaImport,
 		},
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
@@ -216,9 +216,18 @@ func ResourceRdbReadReplicaRead(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
-	directAccess, privateNetwork := flattenReadReplicaEndpoints(rr.Endpoints)
-	_ = d.Set("direct_access", directAccess)
-	_ = d.Set("private_network", privateNetwork)
+	// only the endpoints declared in the direct_access and private_network blocks are tracked
+	directAccessEndpointID := d.Get("direct_access.0.endpoint_id").(string)
+	pnEndpointID := d.Get("private_network.0.endpoint_id").(string)
+	directAccess, privateNetwork := flattenReadReplicaEndpoints(rr.Endpoints, directAccessEndpointID, pnEndpointID)
+
+	if directAccessEndpointID != "" || len(d.Get("direct_access").([]interface{})) > 0 {
+		_ = d.Set("direct_access", directAccess)
+	}
+
+	if pnEndpointID != "" || len(d.Get("private_network").([]interface{})) > 0 {
+		_ = d.Set("private_network", privateNetwork)
+	}
 
 	regionStr := region.String()
 	_ = d.Set("same_zone", rr.SameZone)
@@ -250,9 +259,11 @@ func ResourceRdbReadReplicaUpdate(ctx context.Context, d *schema.ResourceData, m
 	newEndpoints := []*rdb.ReadReplicaEndpointSpec(nil)
 
 	if d.HasChange("direct_access") {
-		// delete old endpoint
+		// delete old endpoint, the ones created by scaleway_rdb_endpoint are kept
+		oldDirectAccessEndpointID := ownedEndpointID(d, "direct_access")
+
 		for _, e := range rr.Endpoints {
-			if e.DirectAccess != nil {
+			if e.DirectAccess != nil && e.ID == oldDirectAccessEndpointID {
 				err := rdbAPI.DeleteEndpoint(&rdb.DeleteEndpointRequest{
 					Region:     region,
 					EndpointID: e.ID,
@@ -274,9 +285,11 @@ func ResourceRdbReadReplicaUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if d.HasChange("private_network") {
-		// delete old endpoint
+		// delete old endpoint, the ones created by scaleway_rdb_endpoint are kept
+		oldPNEndpointID := ownedEndpointID(d, "private_network")
+
 		for _, e := range rr.Endpoints {
-			if e.PrivateNetwork != nil {
+			if e.PrivateNetwork != nil && e.ID == oldPNEndpointID {
 				err := rdbAPI.DeleteEndpoint(&rdb.DeleteEndpointRequest{
 					Region:     region,
 					EndpointID: e.ID,
@@ -287,7 +300,7 @@ func ResourceRdbReadReplicaUpdate(ctx context.Context, d *schema.ResourceData, m
 			}
 		}
 		// retrieve state
-		_, err = waitForRDBReadReplica(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutRead))
+		rr, err = waitForRDBReadReplica(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutRead))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -314,14 +327,23 @@ func ResourceRdbReadReplicaUpdate(ctx context.Context, d *schema.ResourceData, m
 			return diag.FromErr(err)
 		}
 
-		_, err = rdbAPI.CreateReadReplicaEndpoint(&rdb.CreateReadReplicaEndpointRequest{
+		updatedRR, err := rdbAPI.CreateReadReplicaEndpoint(&rdb.CreateReadReplicaEndpointRequest{
 			Region:        region,
 			ReadReplicaID: ID,
 			EndpointSpec:  newEndpoints,
-		})
+		}, scw.WithContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
+
+		for _, endpoint := range createdEndpoints(rr.Endpoints, updatedRR.Endpoints) {
+			switch {
+			case endpoint.DirectAccess != nil:
+				setOwnedEndpointID(d, "direct_access", endpoint.ID)
+			case endpoint.PrivateNetwork != nil:
+				setOwnedEndpointID(d, "private_network", endpoint.ID)
+			}
+		}
 	}
 
 	_, err = waitForRDBReadReplica(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutRead))
@@ -332,6 +354,29 @@ func ResourceRdbReadReplicaUpdate(ctx context.Context, d *schema.ResourceData, m
 	return ResourceRdbReadReplicaRead(ctx, d, m)
 }
 
+// resourceRdbReadReplicaImport claims the first direct access and private network endpoints of the read replica,
+// the other ones are expected to be imported as scaleway_rdb_endpoint
+func resourceRdbReadReplicaImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	rdbAPI, region, ID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	rr, err := rdbAPI.GetReadReplica(&rdb.GetReadReplicaRequest{
+		Region:        region,
+		ReadReplicaID: ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	directAccess, privateNetwork := flattenReadReplicaEndpoints(rr.Endpoints, "", "")
+	_ = d.Set("direct_access", directAccess)
+	_ = d.Set("private_network", privateNetwork)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func ResourceRdbReadReplicaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	rdbAPI, region, ID, err := NewAPIWithRegionAndID(m, d.Id())
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
@@ -83,11 +84,13 @@ func expandLoadBalancer() *rdb.EndpointSpec {
 	}
 }
 
-func flattenPrivateNetwork(endpoints []*rdb.Endpoint) (interface{}, bool) {
+// flattenPrivateNetwork flattens the private network endpoint owned by the instance: the one with endpointID if known,
+// else the first one on privateNetworkID, endpoints created by scaleway_rdb_endpoint are ignored
+func flattenPrivateNetwork(endpoints []*rdb.Endpoint, endpointID string, privateNetworkID string) (interface{}, bool) {
 	pnI := []map[string]interface{}(nil)
 
 	for _, endpoint := range endpoints {
-		if endpoint.PrivateNetwork != nil {
+		if isOwnedPrivateNetworkEndpoint(endpoint, endpointID, privateNetworkID) {
 			pn := endpoint.PrivateNetwork
 
 			fetchRegion, err := pn.Zone.Region()
@@ -125,11 +128,24 @@ func flattenPrivateNetwork(endpoints []*rdb.Endpoint) (interface{}, bool) {
 	return pnI, false
 }
 
-func flattenLoadBalancer(endpoints []*rdb.Endpoint) (interface{}, bool) {
+func isOwnedPrivateNetworkEndpoint(endpoint *rdb.Endpoint, endpointID string, privateNetworkID string) bool {
+	switch {
+	case endpoint.PrivateNetwork == nil:
+		return false
+	case endpointID != "":
+		return endpoint.ID == endpointID
+	default:
+		return privateNetworkID == "" || endpoint.PrivateNetwork.PrivateNetworkID == privateNetworkID
+	}
+}
+
+// flattenLoadBalancer flattens the load balancer endpoint owned by the instance: the one with endpointID if known,
+// else the first one, endpoints created by scaleway_rdb_endpoint are ignored once the endpoint ID is known
+func flattenLoadBalancer(endpoints []*rdb.Endpoint, endpointID string) (interface{}, bool) {
 	flat := []map[string]interface{}(nil)
 
 	for _, endpoint := range endpoints {
-		if endpoint.LoadBalancer != nil {
+		if endpoint.LoadBalancer != nil && (endpointID == "" || endpoint.ID == endpointID) {
 			flat = append(flat, map[string]interface{}{
 				"endpoint_id": endpoint.ID,
 				"ip":          types.FlattenIPPtr(endpoint.IP),
@@ -196,9 +212,18 @@ func expandReadReplicaEndpointsSpecPrivateNetwork(data interface{}, ipamConfig *
 	return endpoint, diags
 }
 
-// flattenReadReplicaEndpoints flatten read-replica endpoints to directAccess and privateNetwork
-func flattenReadReplicaEndpoints(endpoints []*rdb.Endpoint) (directAccess, privateNetwork interface{}) {
+// flattenReadReplicaEndpoints flattens the direct access and private network endpoints owned by the read replica:
+// the ones with the given endpoint IDs if known, else the first ones, endpoints created by scaleway_rdb_endpoint are ignored
+func flattenReadReplicaEndpoints(endpoints []*rdb.Endpoint, directAccessEndpointID string, privateNetworkEndpointID string) (directAccess, privateNetwork interface{}) {
 	for _, endpoint := range endpoints {
+		if endpoint.DirectAccess != nil && (directAccess != nil || (directAccessEndpointID != "" && endpoint.ID != directAccessEndpointID)) {
+			continue
+		}
+
+		if endpoint.PrivateNetwork != nil && (privateNetwork != nil || (privateNetworkEndpointID != "" && endpoint.ID != privateNetworkEndpointID)) {
+			continue
+		}
+
 		rawEndpoint := map[string]interface{}{
 			"endpoint_id": endpoint.ID,
 			"ip":          types.FlattenIPPtr(endpoint.IP),
@@ -271,3 +296,104 @@ func flattenInstanceLogsPolicy(policy *rdb.LogsPolicy) interface{} {
 
 	return p
 }
+
+func flattenUpgradableVersions(versions []*rdb.UpgradableVersion) []map[string]interface{} {
+	flattenedVersions := []map[string]interface{}(nil)
+
+	for _, version := range versions {
+		flattenedVersions = append(flattenedVersions, map[string]interface{}{
+			"id":            version.ID,
+			"name":          version.Name,
+			"version":       version.Version,
+			"minor_version": version.MinorVersion,
+		})
+	}
+
+	return flattenedVersions
+}
+
+func flattenUpgradableVersionNames(versions []*rdb.UpgradableVersion) []string {
+	names := make([]string, 0, len(versions))
+
+	for _, version := range versions {
+		names = append(names, version.Name)
+	}
+
+	return names
+}
+
+// expandEndpointSpec builds the spec of a scaleway_rdb_endpoint, private network endpoints default to IPAM unless a service_ip is given
+func expandEndpointSpec(d *schema.ResourceData) (*rdb.EndpointSpec, error) {
+	if d.Get("load_balancer").(bool) {
+		return expandLoadBalancer(), nil
+	}
+
+	spec := &rdb.EndpointSpec{
+		PrivateNetwork: &rdb.EndpointSpecPrivateNetwork{
+			PrivateNetworkID: locality.ExpandID(d.Get("private_network.0.private_network_id")),
+		},
+	}
+
+	serviceIP, hasServiceIP := d.GetOk("private_network.0.service_ip")
+	if !hasServiceIP {
+		spec.PrivateNetwork.IpamConfig = &rdb.EndpointSpecPrivateNetworkIpamConfig{}
+
+		return spec, nil
+	}
+
+	if enableIpam, isSet := d.GetOk("private_network.0.enable_ipam"); isSet && enableIpam.(bool) {
+		return nil, errors.New("private_network service_ip cannot be set when enable_ipam is true")
+	}
+
+	ipNet, err := types.ExpandIPNet(serviceIP.(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private_network service_ip (%s): %w", serviceIP, err)
+	}
+
+	spec.PrivateNetwork.ServiceIP = &ipNet
+
+	return spec, nil
+}
+
+// expandReadReplicaEndpointSpec converts the private network endpoint spec of an instance to the one of a read replica
+func expandReadReplicaEndpointSpec(spec *rdb.EndpointSpec) *rdb.ReadReplicaEndpointSpec {
+	pnSpec := &rdb.ReadReplicaEndpointSpecPrivateNetwork{
+		PrivateNetworkID: spec.PrivateNetwork.PrivateNetworkID,
+		ServiceIP:        spec.PrivateNetwork.ServiceIP,
+	}
+
+	if spec.PrivateNetwork.IpamConfig != nil {
+		pnSpec.IpamConfig = &rdb.ReadReplicaEndpointSpecPrivateNetworkIpamConfig{}
+	}
+
+	return &rdb.ReadReplicaEndpointSpec{
+		PrivateNetwork: pnSpec,
+	}
+}
+
+func flattenEndpointPrivateNetwork(endpoint *rdb.Endpoint) ([]map[string]interface{}, error) {
+	if endpoint.PrivateNetwork == nil {
+		return nil, nil
+	}
+
+	pn := endpoint.PrivateNetwork
+
+	pnRegion, err := pn.Zone.Region()
+	if err != nil {
+		return nil, err
+	}
+
+	serviceIP, err := types.FlattenIPNet(pn.ServiceIP)
+	if err != nil {
+		return nil, err
+	}
+
+	return []map[string]interface{}{
+		{
+			"private_network_id": regional.NewIDString(pnRegion, pn.PrivateNetworkID),
+			"service_ip":         serviceIP,
+			"enable_ipam":        pn.ProvisioningMode == rdb.EndpointPrivateNetworkDetailsProvisioningModeIpam,
+			"zone":               pn.Zone.String(),
+		},
+	}, nil
+}
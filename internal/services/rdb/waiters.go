@@ -64,3 +64,17 @@ func waitForRDBSnapshot(ctx context.Context, api *rdb.API, region scw.Region, sn
 		RetryInterval: &retryInterval,
 	}, scw.WithContext(ctx))
 }
+
+func waitForRDBInstanceLog(ctx context.Context, api *rdb.API, region scw.Region, id string, timeout time.Duration) (*rdb.InstanceLog, error) {
+	retryInterval := defaultWaitRetryInterval
+	if transport.DefaultWaitRetryInterval != nil {
+		retryInterval = *transport.DefaultWaitRetryInterval
+	}
+
+	return api.WaitForInstanceLog(&rdb.WaitForInstanceLogRequest{
+		Region:        region,
+		Timeout:       scw.TimeDurationPtr(timeout),
+		InstanceLogID: id,
+		RetryInterval: &retryInterval,
+	}, scw.WithContext(ctx))
+}
@@ -0,0 +1,201 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceACLRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceACLRuleCreate,
+		ReadContext:   ResourceACLRuleRead,
+		DeleteContext: ResourceACLRuleDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Delete:  schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Default: schema.DefaultTimeout(defaultRedisClusterTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "UUID of the cluster the rule applies to",
+			},
+			"ip_cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDR,
+				Description:  "IPv4 network address of the rule (IP network in a CIDR format)",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Description of the rule",
+			},
+			// Computed
+			"rule_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the rule",
+			},
+			// Common
+			"zone": zonal.Schema(),
+		},
+		CustomizeDiff: cdf.LocalityCheck("cluster_id"),
+	}
+}
+
+func ResourceACLRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	redisAPI, zone, err := newAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clusterID := locality.ExpandID(d.Get("cluster_id"))
+
+	ipCidr, err := types.ExpandIPNet(d.Get("ip_cidr").(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to validate acl ip (%s): %w", d.Get("ip_cidr").(string), err))
+	}
+
+	_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := redisAPI.AddACLRules(&redis.AddACLRulesRequest{
+		Zone:      zone,
+		ClusterID: clusterID,
+		ACLRules: []*redis.ACLRuleSpec{
+			{
+				IPCidr:      ipCidr,
+				Description: d.Get("description").(string),
+			},
+		},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(res.ACLRules) != 1 {
+		return diag.FromErr(errors.New("acl rule was not returned by the API"))
+	}
+
+	d.SetId(ResourceACLRuleID(zone, clusterID, res.ACLRules[0].ID))
+
+	_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceACLRuleRead(ctx, d, m)
+}
+
+func ResourceACLRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	redisAPI := newAPI(m)
+
+	zone, clusterID, ruleID, err := ResourceACLRuleParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule, err := redisAPI.GetACLRule(&redis.GetACLRuleRequest{
+		Zone:  zone,
+		ACLID: ruleID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("cluster_id", zonal.NewIDString(zone, clusterID))
+	_ = d.Set("rule_id", rule.ID)
+	_ = d.Set("description", types.FlattenStringPtr(rule.Description))
+	_ = d.Set("zone", zone.String())
+
+	if rule.IPCidr != nil {
+		_ = d.Set("ip_cidr", rule.IPCidr.String())
+	}
+
+	return nil
+}
+
+func ResourceACLRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	redisAPI := newAPI(m)
+
+	zone, clusterID, ruleID, err := ResourceACLRuleParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	_, err = redisAPI.DeleteACLRule(&redis.DeleteACLRuleRequest{
+		Zone:  zone,
+		ACLID: ruleID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// ResourceACLRuleID builds the resource identifier
+// The resource identifier format is "Zone/ClusterId/RuleId"
+func ResourceACLRuleID(zone scw.Zone, clusterID string, ruleID string) string {
+	return fmt.Sprintf("%s/%s/%s", zone, clusterID, ruleID)
+}
+
+// ResourceACLRuleParseID extracts the cluster ID and the rule ID from the resource identifier
+// The resource identifier format is "Zone/ClusterId/RuleId"
+func ResourceACLRuleParseID(resourceID string) (zone scw.Zone, clusterID string, ruleID string, err error) {
+	idParts := strings.Split(resourceID, "/")
+	if len(idParts) != 3 {
+		return "", "", "", fmt.Errorf("can't parse acl rule resource id: %s", resourceID)
+	}
+
+	return scw.Zone(idParts[0]), idParts[1], idParts[2], nil
+}
@@ -0,0 +1,177 @@
+package redis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	redisSDK "github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/redis"
+)
+
+func TestAccACLRule_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestRedisVersion := getLatestVersion(tt)
+
+	var officeRuleID string
+
+	clusterConfig := fmt.Sprintf(`
+		resource "scaleway_redis_cluster" "main" {
+		  name      = "test_redis_acl_rule"
+		  version   = "%s"
+		  node_type = "RED1-XS"
+		  user_name = "my_initial_user"
+		  password  = "thiZ_is_v&ry_s3cret"
+		}
+	`, latestRedisVersion)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      isClusterDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: clusterConfig + `
+					resource "scaleway_redis_acl_rule" "office" {
+					  cluster_id  = scaleway_redis_cluster.main.id
+					  ip_cidr     = "192.168.10.0/24"
+					  description = "Office"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isACLRulePresent(tt, "scaleway_redis_acl_rule.office"),
+					resource.TestCheckResourceAttrPair("scaleway_redis_acl_rule.office", "cluster_id", "scaleway_redis_cluster.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_redis_acl_rule.office", "ip_cidr", "192.168.10.0/24"),
+					resource.TestCheckResourceAttr("scaleway_redis_acl_rule.office", "description", "Office"),
+					resource.TestCheckResourceAttrSet("scaleway_redis_acl_rule.office", "rule_id"),
+				),
+			},
+			{
+				// Adding a rule keeps the one already created
+				Config: clusterConfig + `
+					resource "scaleway_redis_acl_rule" "office" {
+					  cluster_id  = scaleway_redis_cluster.main.id
+					  ip_cidr     = "192.168.10.0/24"
+					  description = "Office"
+					}
+
+					resource "scaleway_redis_acl_rule" "vpn" {
+					  cluster_id  = scaleway_redis_cluster.main.id
+					  ip_cidr     = "10.0.0.0/16"
+					  description = "VPN"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isACLRulePresent(tt, "scaleway_redis_acl_rule.office"),
+					isACLRulePresent(tt, "scaleway_redis_acl_rule.vpn"),
+					resource.TestCheckResourceAttr("scaleway_redis_acl_rule.vpn", "ip_cidr", "10.0.0.0/16"),
+					resource.TestCheckResourceAttr("scaleway_redis_acl_rule.vpn", "description", "VPN"),
+				),
+			},
+			{
+				// Changing the network of a rule replaces it
+				Config: clusterConfig + `
+					resource "scaleway_redis_acl_rule" "office" {
+					  cluster_id  = scaleway_redis_cluster.main.id
+					  ip_cidr     = "192.168.11.0/24"
+					  description = "Office"
+					}
+
+					resource "scaleway_redis_acl_rule" "vpn" {
+					  cluster_id  = scaleway_redis_cluster.main.id
+					  ip_cidr     = "10.0.0.0/16"
+					  description = "VPN"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isACLRulePresent(tt, "scaleway_redis_acl_rule.office"),
+					isACLRulePresent(tt, "scaleway_redis_acl_rule.vpn"),
+					resource.TestCheckResourceAttr("scaleway_redis_acl_rule.office", "ip_cidr", "192.168.11.0/24"),
+					storeACLRuleID("scaleway_redis_acl_rule.office", &officeRuleID),
+				),
+			},
+			{
+				ResourceName:      "scaleway_redis_acl_rule.office",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Removing a rule deletes it from the cluster
+				Config: clusterConfig + `
+					resource "scaleway_redis_acl_rule" "vpn" {
+					  cluster_id  = scaleway_redis_cluster.main.id
+					  ip_cidr     = "10.0.0.0/16"
+					  description = "VPN"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isACLRulePresent(tt, "scaleway_redis_acl_rule.vpn"),
+					isACLRuleDestroyed(tt, &officeRuleID),
+				),
+			},
+		},
+	})
+}
+
+func isACLRulePresent(tt *acctest.TestTools, n string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		zone, _, ruleID, err := redis.ResourceACLRuleParseID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = redisSDK.NewAPI(tt.Meta.ScwClient()).GetACLRule(&redisSDK.GetACLRuleRequest{
+			Zone:  zone,
+			ACLID: ruleID,
+		})
+
+		return err
+	}
+}
+
+// storeACLRuleID saves the ID of a rule so that its deletion can be checked once it is removed from the state
+func storeACLRuleID(n string, id *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		*id = rs.Primary.ID
+
+		return nil
+	}
+}
+
+func isACLRuleDestroyed(tt *acctest.TestTools, id *string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		zone, _, ruleID, err := redis.ResourceACLRuleParseID(*id)
+		if err != nil {
+			return err
+		}
+
+		_, err = redisSDK.NewAPI(tt.Meta.ScwClient()).GetACLRule(&redisSDK.GetACLRuleRequest{
+			Zone:  zone,
+			ACLID: ruleID,
+		})
+		if err == nil {
+			return fmt.Errorf("acl rule (%s) still exists", *id)
+		}
+
+		if !httperrors.Is404(err) {
+			return err
+		}
+
+		return nil
+	}
+}
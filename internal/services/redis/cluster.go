@@ -90,8 +90,10 @@ func ResourceCluster() *schema.Resource {
 			},
 			"acl": {
 				Type:          schema.TypeSet,
-				Description:   "List of acl rules.",
+				Description:   "List of acl rules, set to an empty list to remove all the rules.",
 				Optional:      true,
+				Computed:      true,
+				ConfigMode:    schema.SchemaConfigModeAttr,
 				ConflictsWith: []string{"private_network"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -117,8 +119,9 @@ func ResourceCluster() *schema.Resource {
 			},
 			"settings": {
 				Type:        schema.TypeMap,
-				Description: "Map of settings to define for the cluster.",
+				Description: "Map of settings to define for the cluster, set to an empty map to remove all the settings.",
 				Optional:    true,
+				Computed:    true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -215,6 +218,7 @@ func ResourceCluster() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			cdf.LocalityCheck("private_network.#.id"),
 			customizeDiffMigrateClusterSize(),
+			customizeDiffClearOnEmpty("acl", "settings"),
 		),
 	}
 }
@@ -233,6 +237,53 @@ func customizeDiffMigrateClusterSize() schema.CustomizeDiffFunc {
 	}
 }
 
+// customizeDiffClearOnEmpty plans the removal of every element of computed collections explicitly set to empty,
+// collections missing from the configuration keep the elements managed outside of the cluster
+func customizeDiffClearOnEmpty(keys ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		if diff.Id() == "" {
+			return nil
+		}
+
+		rawConfig := diff.GetRawConfig()
+		if rawConfig.IsNull() || !rawConfig.IsKnown() {
+			return nil
+		}
+
+		for _, key := range keys {
+			rawValue := rawConfig.GetAttr(key)
+			if rawValue.IsNull() || !rawValue.IsKnown() || rawValue.LengthInt() > 0 {
+				continue
+			}
+
+			oldValue, _ := diff.GetChange(key)
+
+			switch value := oldValue.(type) {
+			case *schema.Set:
+				if value.Len() == 0 {
+					continue
+				}
+
+				err := diff.SetNew(key, []interface{}{})
+				if err != nil {
+					return err
+				}
+			case map[string]interface{}:
+				if len(value) == 0 {
+					continue
+				}
+
+				err := diff.SetNew(key, map[string]interface{}{})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
 func ResourceClusterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	redisAPI, zone, err := newAPIWithZone(d, m)
 	if err != nil {
@@ -0,0 +1,282 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceClusterSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceClusterSettingsCreate,
+		ReadContext:   ResourceClusterSettingsRead,
+		UpdateContext: ResourceClusterSettingsUpdate,
+		DeleteContext: ResourceClusterSettingsDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Update:  schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Delete:  schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Default: schema.DefaultTimeout(defaultRedisClusterTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "UUID of the cluster the settings apply to",
+			},
+			"settings": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Description: "Map of settings to define for the cluster",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// Common
+			"zone": zonal.Schema(),
+		},
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("cluster_id"),
+			customizeDiffClusterSettings,
+		),
+	}
+}
+
+// customizeDiffClusterSettings validates the settings against the version of the cluster when it already exists
+func customizeDiffClusterSettings(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.HasChange("settings") || !diff.NewValueKnown("settings") || !diff.NewValueKnown("cluster_id") {
+		return nil
+	}
+
+	// A bare cluster UUID is in the zone of the resource, which defaults to the provider zone
+	zone, clusterID, err := zonal.ParseID(diff.Get("cluster_id").(string))
+	if err != nil {
+		zone, err = meta.ExtractZone(diff, m)
+		if err != nil {
+			return err
+		}
+
+		clusterID = locality.ExpandID(diff.Get("cluster_id"))
+	}
+
+	redisAPI := newAPI(m)
+
+	cluster, err := redisAPI.GetCluster(&redis.GetClusterRequest{
+		Zone:      zone,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			// The cluster is replaced, the settings are validated on apply
+			return nil
+		}
+
+		return err
+	}
+
+	return validateClusterSettings(ctx, redisAPI, zone, cluster.Version, diff.Get("settings").(map[string]interface{}))
+}
+
+func validateClusterSettings(ctx context.Context, redisAPI *redis.API, zone scw.Zone, version string, settings map[string]interface{}) error {
+	availableSettings, err := getClusterVersionSettings(ctx, redisAPI, zone, version)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateClusterSettings(settings, availableSettings)
+	if err != nil {
+		return fmt.Errorf("invalid settings for redis version %s: %w", version, err)
+	}
+
+	return nil
+}
+
+func ResourceClusterSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	redisAPI, zone, err := newAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clusterID := locality.ExpandID(d.Get("cluster_id"))
+	settings := d.Get("settings").(map[string]interface{})
+
+	cluster, err := waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = validateClusterSettings(ctx, redisAPI, zone, cluster.Version, settings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = redisAPI.AddClusterSettings(&redis.AddClusterSettingsRequest{
+		Zone:      zone,
+		ClusterID: clusterID,
+		Settings:  expandSettings(settings),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(zonal.NewIDString(zone, clusterID))
+
+	_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceClusterSettingsRead(ctx, d, m)
+}
+
+func ResourceClusterSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	redisAPI, zone, clusterID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cluster, err := redisAPI.GetCluster(&redis.GetClusterRequest{
+		Zone:      zone,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	// Only the settings managed by this resource are tracked, all of them are tracked on import
+	managedSettings := d.Get("settings").(map[string]interface{})
+	settings := map[string]string{}
+
+	for _, setting := range cluster.ClusterSettings {
+		if _, isManaged := managedSettings[setting.Name]; isManaged || len(managedSettings) == 0 {
+			settings[setting.Name] = setting.Value
+		}
+	}
+
+	_ = d.Set("cluster_id", zonal.NewIDString(zone, clusterID))
+	_ = d.Set("settings", settings)
+	_ = d.Set("zone", zone.String())
+
+	return nil
+}
+
+func ResourceClusterSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	redisAPI, zone, clusterID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("settings") {
+		oldSettings, newSettings := d.GetChange("settings")
+
+		cluster, err := waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = validateClusterSettings(ctx, redisAPI, zone, cluster.Version, newSettings.(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		removedSettings := []string(nil)
+
+		for name := range oldSettings.(map[string]interface{}) {
+			if _, kept := newSettings.(map[string]interface{})[name]; !kept {
+				removedSettings = append(removedSettings, name)
+			}
+		}
+
+		err = deleteClusterSettings(ctx, redisAPI, zone, clusterID, removedSettings, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = redisAPI.AddClusterSettings(&redis.AddClusterSettingsRequest{
+			Zone:      zone,
+			ClusterID: clusterID,
+			Settings:  expandSettings(newSettings),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ResourceClusterSettingsRead(ctx, d, m)
+}
+
+func ResourceClusterSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	redisAPI, zone, clusterID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	settingNames := []string(nil)
+	for name := range d.Get("settings").(map[string]interface{}) {
+		settingNames = append(settingNames, name)
+	}
+
+	err = deleteClusterSettings(ctx, redisAPI, zone, clusterID, settingNames, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// deleteClusterSettings resets the given settings to their default value, the API deletes them one at a time
+func deleteClusterSettings(ctx context.Context, redisAPI *redis.API, zone scw.Zone, clusterID string, settingNames []string, timeout time.Duration) error {
+	sort.Strings(settingNames)
+
+	for _, name := range settingNames {
+		_, err := waitForCluster(ctx, redisAPI, zone, clusterID, timeout)
+		if err != nil {
+			return err
+		}
+
+		_, err = redisAPI.DeleteClusterSetting(&redis.DeleteClusterSettingRequest{
+			Zone:        zone,
+			ClusterID:   clusterID,
+			SettingName: name,
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			return fmt.Errorf("failed to delete setting %s: %w", name, err)
+		}
+	}
+
+	_, err := waitForCluster(ctx, redisAPI, zone, clusterID, timeout)
+
+	return err
+}
@@ -0,0 +1,124 @@
+package redis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	redisSDK "github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/redis"
+)
+
+func TestAccClusterSettings_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestRedisVersion := getLatestVersion(tt)
+
+	clusterConfig := fmt.Sprintf(`
+		resource "scaleway_redis_cluster" "main" {
+		  name      = "test_redis_cluster_settings"
+		  version   = "%s"
+		  node_type = "RED1-XS"
+		  user_name = "my_initial_user"
+		  password  = "thiZ_is_v&ry_s3cret"
+		}
+	`, latestRedisVersion)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      isClusterDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: clusterConfig + `
+					resource "scaleway_redis_cluster_settings" "main" {
+					  cluster_id = scaleway_redis_cluster.main.id
+					  settings = {
+						"tcp-keepalive" = "150"
+						"maxclients"    = "5000"
+					  }
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_redis_cluster_settings.main", "cluster_id", "scaleway_redis_cluster.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_redis_cluster_settings.main", "settings.%", "2"),
+					isClusterSettingSet(tt, "scaleway_redis_cluster.main", "tcp-keepalive", "150"),
+					isClusterSettingSet(tt, "scaleway_redis_cluster.main", "maxclients", "5000"),
+				),
+			},
+			{
+				// Updating a value and removing a setting is done in place
+				Config: clusterConfig + `
+					resource "scaleway_redis_cluster_settings" "main" {
+					  cluster_id = scaleway_redis_cluster.main.id
+					  settings = {
+						"maxclients" = "2000"
+					  }
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_redis_cluster_settings.main", "settings.%", "1"),
+					resource.TestCheckResourceAttr("scaleway_redis_cluster_settings.main", "settings.maxclients", "2000"),
+					isClusterSettingSet(tt, "scaleway_redis_cluster.main", "maxclients", "2000"),
+					isClusterSettingSet(tt, "scaleway_redis_cluster.main", "tcp-keepalive", ""),
+				),
+			},
+			{
+				ResourceName:      "scaleway_redis_cluster_settings.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Removing the resource deletes its settings from the cluster
+				Config: clusterConfig,
+				Check: resource.ComposeTestCheckFunc(
+					isClusterSettingSet(tt, "scaleway_redis_cluster.main", "maxclients", ""),
+				),
+			},
+		},
+	})
+}
+
+// isClusterSettingSet checks the value of a setting on the cluster, an empty value checks that the setting is not defined
+func isClusterSettingSet(tt *acctest.TestTools, n string, name string, value string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		redisAPI, zone, ID, err := redis.NewAPIWithZoneAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		cluster, err := redisAPI.GetCluster(&redisSDK.GetClusterRequest{
+			ClusterID: ID,
+			Zone:      zone,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, setting := range cluster.ClusterSettings {
+			if setting.Name != name {
+				continue
+			}
+
+			if setting.Value != value {
+				return fmt.Errorf("setting %s of cluster %s is %q, expected %q", name, ID, setting.Value, value)
+			}
+
+			return nil
+		}
+
+		if value != "" {
+			return fmt.Errorf("setting %s is not defined on cluster %s", name, ID)
+		}
+
+		return nil
+	}
+}
@@ -294,6 +294,35 @@ func TestAccCluster_ACL(t *testing.T) {
 					resource.TestCheckResourceAttrSet("scaleway_redis_cluster.main", "acl.0.id"),
 				),
 			},
+			{
+				// Rules missing from the configuration are kept, they may be managed by scaleway_redis_acl_rule
+				Config: fmt.Sprintf(`
+				resource "scaleway_redis_cluster" "main" {
+				  name      = "test_redis_acl"
+				  version   = "%s"
+				  node_type = "RED1-XS"
+				  user_name = "my_initial_user"
+				  password  = "thiZ_is_v&ry_s3cret"
+				}
+				`, latestRedisVersion),
+				PlanOnly: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "scaleway_redis_cluster" "main" {
+				  name      = "test_redis_acl"
+				  version   = "%s"
+				  node_type = "RED1-XS"
+				  user_name = "my_initial_user"
+				  password  = "thiZ_is_v&ry_s3cret"
+				  acl       = []
+				}
+				`, latestRedisVersion),
+				Check: resource.ComposeTestCheckFunc(
+					isClusterPresent(tt, "scaleway_redis_cluster.main"),
+					resource.TestCheckResourceAttr("scaleway_redis_cluster.main", "acl.#", "0"),
+				),
+			},
 		},
 	})
 }
@@ -361,6 +390,35 @@ func TestAccCluster_Settings(t *testing.T) {
 					resource.TestCheckResourceAttrSet("scaleway_redis_cluster.main", "public_network.0.ips.#"),
 				),
 			},
+			{
+				// Settings missing from the configuration are kept, they may be managed by scaleway_redis_cluster_settings
+				Config: fmt.Sprintf(`
+					resource "scaleway_redis_cluster" "main" {
+						name = "test_redis_settings"
+						version = "%s"
+						node_type = "RED1-XS"
+						user_name = "my_initial_user"
+						password = "thiZ_is_v&ry_s3cret"
+					}
+				`, latestRedisVersion),
+				PlanOnly: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "scaleway_redis_cluster" "main" {
+						name = "test_redis_settings"
+						version = "%s"
+						node_type = "RED1-XS"
+						user_name = "my_initial_user"
+						password = "thiZ_is_v&ry_s3cret"
+						settings = {}
+					}
+				`, latestRedisVersion),
+				Check: resource.ComposeTestCheckFunc(
+					isClusterPresent(tt, "scaleway_redis_cluster.main"),
+					resource.TestCheckResourceAttr("scaleway_redis_cluster.main", "settings.%", "0"),
+				),
+			},
 		},
 	})
 }
@@ -3,8 +3,12 @@ package redis
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -83,3 +87,102 @@ func privateNetworkSetHash(v interface{}) int {
 
 	return types.StringHashcode(buf.String())
 }
+
+// getClusterVersionSettings returns the settings available for a redis version
+func getClusterVersionSettings(ctx context.Context, api *redis.API, zone scw.Zone, version string) ([]*redis.AvailableClusterSetting, error) {
+	res, err := api.ListClusterVersions(&redis.ListClusterVersionsRequest{
+		Zone:              zone,
+		Version:           scw.StringPtr(version),
+		IncludeBeta:       true,
+		IncludeDeprecated: true,
+		IncludeDisabled:   true,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, clusterVersion := range res.Versions {
+		if clusterVersion.Version == version {
+			return clusterVersion.AvailableSettings, nil
+		}
+	}
+
+	return nil, fmt.Errorf("redis version %s is not available in zone %s", version, zone)
+}
+
+// ValidateClusterSettings checks every setting against the settings available for the cluster version,
+// all the invalid settings are reported at once
+func ValidateClusterSettings(settings map[string]interface{}, availableSettings []*redis.AvailableClusterSetting) error {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	errs := []error(nil)
+
+	for _, name := range names {
+		var setting *redis.AvailableClusterSetting
+
+		for _, availableSetting := range availableSettings {
+			if availableSetting.Name == name {
+				setting = availableSetting
+
+				break
+			}
+		}
+
+		if setting == nil {
+			errs = append(errs, fmt.Errorf("%s is not a setting available for this redis version", name))
+
+			continue
+		}
+
+		err := validateClusterSettingValue(setting, fmt.Sprint(settings[name]))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid value for %s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateClusterSettingValue(setting *redis.AvailableClusterSetting, value string) error {
+	switch setting.Type {
+	case redis.AvailableClusterSettingPropertyTypeBOOLEAN:
+		switch strings.ToLower(value) {
+		case "yes", "no", "true", "false":
+			return nil
+		}
+
+		return fmt.Errorf("%q is not a boolean", value)
+	case redis.AvailableClusterSettingPropertyTypeINT:
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+
+		if setting.MinValue != nil && intValue < *setting.MinValue {
+			return fmt.Errorf("%d is lower than the minimum %d", intValue, *setting.MinValue)
+		}
+
+		if setting.MaxValue != nil && intValue > *setting.MaxValue {
+			return fmt.Errorf("%d is greater than the maximum %d", intValue, *setting.MaxValue)
+		}
+	case redis.AvailableClusterSettingPropertyTypeSTRING:
+		if setting.Regex != nil && *setting.Regex != "" {
+			regex, err := regexp.Compile(*setting.Regex)
+			if err != nil {
+				// The regex is meant for the API, do not block the user if it cannot be compiled in Go
+				return nil //nolint:nilerr
+			}
+
+			if !regex.MatchString(value) {
+				return fmt.Errorf("%q does not match %s", value, *setting.Regex)
+			}
+		}
+	}
+
+	return nil
+}
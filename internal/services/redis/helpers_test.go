@@ -0,0 +1,55 @@
+package redis_test
+
+import (
+	"testing"
+
+	redisSDK "github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateClusterSettings(t *testing.T) {
+	availableSettings := []*redisSDK.AvailableClusterSetting{
+		{Name: "maxclients", Type: redisSDK.AvailableClusterSettingPropertyTypeINT, MinValue: scw.Int64Ptr(100), MaxValue: scw.Int64Ptr(10000)},
+		{Name: "tcp-keepalive", Type: redisSDK.AvailableClusterSettingPropertyTypeINT, MinValue: scw.Int64Ptr(0)},
+		{Name: "lazyfree-lazy-eviction", Type: redisSDK.AvailableClusterSettingPropertyTypeBOOLEAN},
+		{Name: "maxmemory-policy", Type: redisSDK.AvailableClusterSettingPropertyTypeSTRING, Regex: scw.StringPtr("^(noeviction|allkeys-lru|volatile-lru)$")},
+	}
+
+	require.NoError(t, redis.ValidateClusterSettings(map[string]interface{}{
+		"maxclients":             "5000",
+		"tcp-keepalive":          "150",
+		"lazyfree-lazy-eviction": "yes",
+		"maxmemory-policy":       "allkeys-lru",
+	}, availableSettings))
+
+	err := redis.ValidateClusterSettings(map[string]interface{}{
+		"maxclients":             "50000",
+		"tcp-keepalive":          "-1",
+		"lazyfree-lazy-eviction": "maybe",
+		"maxmemory-policy":       "random",
+		"max-clients":            "5000",
+	}, availableSettings)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid value for maxclients: 50000 is greater than the maximum 10000")
+	assert.Contains(t, err.Error(), "invalid value for tcp-keepalive: -1 is lower than the minimum 0")
+	assert.Contains(t, err.Error(), `invalid value for lazyfree-lazy-eviction: "maybe" is not a boolean`)
+	assert.Contains(t, err.Error(), `invalid value for maxmemory-policy: "random" does not match`)
+	assert.Contains(t, err.Error(), "max-clients is not a setting available for this redis version")
+}
+
+func TestACLRuleParseID(t *testing.T) {
+	id := redis.ResourceACLRuleID(scw.ZoneFrPar1, "clusterid", "ruleid")
+	assert.Equal(t, "fr-par-1/clusterid/ruleid", id)
+
+	zone, clusterID, ruleID, err := redis.ResourceACLRuleParseID(id)
+	require.NoError(t, err)
+	assert.Equal(t, scw.ZoneFrPar1, zone)
+	assert.Equal(t, "clusterid", clusterID)
+	assert.Equal(t, "ruleid", ruleID)
+
+	_, _, _, err = redis.ResourceACLRuleParseID("notanid")
+	require.Error(t, err)
+}
@@ -0,0 +1,288 @@
+package registry
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+	dockerRegistrySDK "github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/fileset"
+)
+
+const (
+	dockerIgnoreFile       = ".dockerignore"
+	registryTokenUsername  = "nologin"
+	loadedImagePrefix      = "Loaded image: "
+	loadedImageIDPrefix    = "Loaded image ID: "
+	defaultImageDockerfile = "Dockerfile"
+)
+
+func newDockerClient() (*client.Client, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to Docker: %w", err)
+	}
+
+	return cli, nil
+}
+
+// encodeRegistryAuth encodes the credentials of a registry as expected by the docker daemon
+func encodeRegistryAuth(endpoint string, token string) (string, error) {
+	encodedJSON, err := json.Marshal(dockerRegistrySDK.AuthConfig{
+		ServerAddress: endpoint,
+		Username:      registryTokenUsername,
+		Password:      token,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not marshal auth config: %w", err)
+	}
+
+	return base64.URLEncoding.EncodeToString(encodedJSON), nil
+}
+
+// readDockerStream consumes the JSON messages of a docker operation and returns them, failing on the first error
+func readDockerStream(stream io.Reader) ([]*jsonmessage.JSONMessage, error) {
+	var messages []*jsonmessage.JSONMessage
+
+	decoder := json.NewDecoder(bufio.NewReader(stream))
+
+	for {
+		message := &jsonmessage.JSONMessage{}
+
+		err := decoder.Decode(message)
+		if errors.Is(err, io.EOF) {
+			return messages, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("could not decode docker output: %w", err)
+		}
+
+		if message.Error != nil {
+			return nil, message.Error
+		}
+
+		if message.ErrorMessage != "" {
+			return nil, errors.New(message.ErrorMessage)
+		}
+
+		messages = append(messages, message)
+	}
+}
+
+// buildImage builds the image of the context directory, tagged as set in the options
+func buildImage(ctx context.Context, cli *client.Client, contextDir string, options dockerTypes.ImageBuildOptions) error {
+	buildContext, err := BuildContextArchive(contextDir)
+	if err != nil {
+		return err
+	}
+
+	res, err := cli.ImageBuild(ctx, bytes.NewReader(buildContext), options)
+	if err != nil {
+		return fmt.Errorf("could not build image: %w", err)
+	}
+	defer res.Body.Close()
+
+	_, err = readDockerStream(res.Body)
+	if err != nil {
+		return fmt.Errorf("could not build image: %w", err)
+	}
+
+	return nil
+}
+
+// loadImage loads an image tarball and returns the reference of the loaded image
+func loadImage(ctx context.Context, cli *client.Client, tarballPath string) (string, error) {
+	tarball, err := os.Open(tarballPath)
+	if err != nil {
+		return "", fmt.Errorf("could not open image tarball: %w", err)
+	}
+	defer tarball.Close()
+
+	res, err := cli.ImageLoad(ctx, tarball, true)
+	if err != nil {
+		return "", fmt.Errorf("could not load image tarball: %w", err)
+	}
+	defer res.Body.Close()
+
+	messages, err := readDockerStream(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("could not load image tarball: %w", err)
+	}
+
+	for _, message := range messages {
+		stream := strings.TrimSpace(message.Stream)
+
+		switch {
+		case strings.HasPrefix(stream, loadedImagePrefix):
+			return strings.TrimPrefix(stream, loadedImagePrefix), nil
+		case strings.HasPrefix(stream, loadedImageIDPrefix):
+			return strings.TrimPrefix(stream, loadedImageIDPrefix), nil
+		}
+	}
+
+	return "", errors.New("could not find the loaded image in the tarball")
+}
+
+// pushImage pushes the image and returns its digest
+func pushImage(ctx context.Context, cli *client.Client, imageRef string, registryAuth string) (string, error) {
+	res, err := cli.ImagePush(ctx, imageRef, image.PushOptions{RegistryAuth: registryAuth})
+	if err != nil {
+		return "", fmt.Errorf("could not push image: %w", err)
+	}
+	defer res.Close()
+
+	messages, err := readDockerStream(res)
+	if err != nil {
+		return "", fmt.Errorf("could not push image: %w", err)
+	}
+
+	for _, message := range messages {
+		if message.Aux == nil {
+			continue
+		}
+
+		pushResult := dockerTypes.PushResult{}
+
+		err = json.Unmarshal(*message.Aux, &pushResult)
+		if err == nil && pushResult.Digest != "" {
+			return pushResult.Digest, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not find the digest of pushed image %s", imageRef)
+}
+
+// BuildContextArchive returns a deterministic tar archive of the context directory, without the files matched by its .dockerignore
+func BuildContextArchive(contextDir string) ([]byte, error) {
+	ignorePatterns, err := fileset.ReadIgnoreFile(filepath.Join(contextDir, dockerIgnoreFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+
+	err = fileset.Walk(contextDir, ignorePatterns, func(relPath string, _ fs.DirEntry) error {
+		paths = append(paths, relPath)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read build context %s: %w", contextDir, err)
+	}
+
+	sort.Strings(paths)
+
+	buf := &bytes.Buffer{}
+	tarWriter := tar.NewWriter(buf)
+
+	for _, relPath := range paths {
+		err = addFileToTar(tarWriter, filepath.Join(contextDir, filepath.FromSlash(relPath)), relPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not archive %s: %w", relPath, err)
+		}
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func addFileToTar(tarWriter *tar.Writer, path string, name string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	link := ""
+	if info.Mode()&os.ModeSymlink != 0 {
+		link, err = os.Readlink(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+
+	// Metadata that changes between checkouts would change the archive hash
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+
+	header.ModTime = time.Unix(0, 0)
+	header.AccessTime = time.Time{}
+	header.ChangeTime = time.Time{}
+	header.Uid, header.Gid = 0, 0
+	header.Uname, header.Gname = "", ""
+
+	err = tarWriter.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(tarWriter, file)
+
+	return err
+}
+
+// sourceSHA256 returns the hash of the build context or of the image tarball
+func sourceSHA256(contextDir string, tarballPath string) (string, error) {
+	hash := sha256.New()
+
+	if contextDir != "" {
+		buildContext, err := BuildContextArchive(contextDir)
+		if err != nil {
+			return "", err
+		}
+
+		hash.Write(buildContext)
+	} else {
+		tarball, err := os.Open(tarballPath)
+		if err != nil {
+			return "", fmt.Errorf("could not open image tarball: %w", err)
+		}
+		defer tarball.Close()
+
+		_, err = io.Copy(hash, tarball)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
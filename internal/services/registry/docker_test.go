@@ -0,0 +1,62 @@
+package registry_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildContextArchive(t *testing.T) {
+	contextDir := t.TempDir()
+
+	files := map[string]string{
+		"Dockerfile":          "FROM scratch\nCOPY app /app\n",
+		"app":                 "binary",
+		".dockerignore":       "# comments are ignored\n*.log\n!keep.log\nnode_modules/\n",
+		"keep.log":            "included again",
+		"debug.log":           "ignored",
+		"node_modules/dep.js": "ignored",
+		"src/main.go":         "package main",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(contextDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	archive, err := registry.BuildContextArchive(contextDir)
+	require.NoError(t, err)
+
+	var names []string
+
+	reader := tar.NewReader(bytes.NewReader(archive))
+
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		names = append(names, header.Name)
+	}
+
+	assert.Equal(t, []string{".dockerignore", "Dockerfile", "app", "keep.log", "src/", "src/main.go"}, names)
+
+	// The archive does not depend on the modification time of the files
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(contextDir, "app"), later, later))
+
+	sameArchive, err := registry.BuildContextArchive(contextDir)
+	require.NoError(t, err)
+	assert.Equal(t, archive, sameArchive)
+}
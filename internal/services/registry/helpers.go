@@ -1,11 +1,14 @@
 package registry
 
 import (
+	"context"
+	"sort"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 )
@@ -13,6 +16,7 @@ import (
 const (
 	defaultNamespaceTimeout       = 5 * time.Minute
 	defaultNamespaceRetryInterval = 5 * time.Second
+	defaultImageTimeout           = 20 * time.Minute
 )
 
 type ErrorRegistryMessage struct {
@@ -42,3 +46,94 @@ func NewAPIWithRegionAndID(m interface{}, id string) (*registry.API, scw.Region,
 
 	return api, region, id, nil
 }
+
+// newAPIWithRegionFromNamespaceID returns a new container registry API and the region of the namespace.
+func newAPIWithRegionFromNamespaceID(d *schema.ResourceData, m interface{}, namespaceID string) (*registry.API, scw.Region, error) {
+	if region := regional.ExpandID(namespaceID).Region; region != "" {
+		return registry.NewAPI(meta.ExtractScwClient(m)), region, nil
+	}
+
+	return NewAPIWithRegion(d, m)
+}
+
+// findImageTag returns the tag of an image in a namespace, nil if the image or the tag does not exist.
+func findImageTag(ctx context.Context, api *registry.API, region scw.Region, namespaceID string, imageName string, tagName string) (*registry.Tag, error) {
+	images, err := api.ListImages(&registry.ListImagesRequest{
+		Region:      region,
+		NamespaceID: &namespaceID,
+		Name:        &imageName,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	imageID := ""
+
+	for _, image := range images.Images {
+		if image.Name == imageName {
+			imageID = image.ID
+		}
+	}
+
+	if imageID == "" {
+		return nil, nil
+	}
+
+	tags, err := api.ListTags(&registry.ListTagsRequest{
+		Region:  region,
+		ImageID: imageID,
+		Name:    &tagName,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	for _, tag := range tags.Tags {
+		if tag.Name == tagName {
+			return tag, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func expandImageBuildArgs(raw interface{}) map[string]*string {
+	buildArgs := make(map[string]*string)
+	for key, value := range raw.(map[string]interface{}) {
+		buildArgs[key] = scw.StringPtr(value.(string))
+	}
+
+	return buildArgs
+}
+
+// findTagsSharingDigest returns the names of the other tags of the image pointing to the same digest as the tag.
+// The registry can only delete such a tag along with the others
+func findTagsSharingDigest(ctx context.Context, api *registry.API, region scw.Region, tag *registry.Tag) ([]string, error) {
+	tags, err := api.ListTags(&registry.ListTagsRequest{
+		Region:  region,
+		ImageID: tag.ImageID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var sharingTags []string
+
+	for _, otherTag := range tags.Tags {
+		if otherTag.ID != tag.ID && otherTag.Digest == tag.Digest {
+			sharingTags = append(sharingTags, otherTag.Name)
+		}
+	}
+
+	sort.Strings(sharingTags)
+
+	return sharingTags, nil
+}
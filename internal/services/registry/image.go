@@ -0,0 +1,334 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceImage() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceImageCreate,
+		ReadContext:   ResourceImageRead,
+		UpdateContext: ResourceImageUpdate,
+		DeleteContext: ResourceImageDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultImageTimeout),
+			Default: schema.DefaultTimeout(defaultNamespaceTimeout),
+		},
+		SchemaVersion: 0,
+		CustomizeDiff: customizeDiffImageSourceSHA256,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the image in the registry",
+			},
+			"tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "latest",
+				Description: "The tag of the pushed image",
+			},
+			"namespace_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "The ID of the registry namespace to push the image to",
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				ExactlyOneOf:     []string{"namespace_id", "registry_endpoint"},
+			},
+			"registry_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The endpoint of the registry to push the image to, defaults to the endpoint of the namespace",
+			},
+			"build": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				Description:  "Build the image from a local context directory",
+				ExactlyOneOf: []string{"build", "tarball"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"context": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The path of the build context directory",
+						},
+						"dockerfile": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     defaultImageDockerfile,
+							Description: "The path of the Dockerfile, relative to the context directory",
+						},
+						"build_args": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Build-time variables passed to the build",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"platform": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The platform of the image, e.g. linux/amd64",
+						},
+					},
+				},
+			},
+			"tarball": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The path of an image tarball, as created by docker save, to push instead of building",
+			},
+			"registry_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The token used to push to the registry, defaults to the secret key of the provider",
+			},
+			"keep_remotely": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Keep the tag in the registry namespace when the resource is destroyed",
+			},
+			"image_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the pushed image, with its tag",
+			},
+			"digest": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The digest of the pushed image",
+			},
+			"source_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA256 of the build context or of the tarball, a change rebuilds the image",
+			},
+			"region": regional.ComputedSchema(),
+		},
+	}
+}
+
+func ResourceImageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	endpoint := d.Get("registry_endpoint").(string)
+
+	if namespaceID, ok := d.GetOk("namespace_id"); ok {
+		api, region, err := newAPIWithRegionFromNamespaceID(d, m, namespaceID.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ns, err := WaitForNamespace(ctx, api, region, regional.ExpandID(namespaceID).ID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		endpoint = ns.Endpoint
+		_ = d.Set("region", region.String())
+	}
+
+	imageRef := endpoint + "/" + d.Get("name").(string) + ":" + d.Get("tag").(string)
+
+	cli, err := newDockerClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer cli.Close()
+
+	if rawBuild, ok := d.GetOk("build.0"); ok {
+		build := rawBuild.(map[string]interface{})
+
+		err = buildImage(ctx, cli, build["context"].(string), dockerTypes.ImageBuildOptions{
+			Tags:       []string{imageRef},
+			Dockerfile: build["dockerfile"].(string),
+			BuildArgs:  expandImageBuildArgs(build["build_args"]),
+			Platform:   build["platform"].(string),
+			Remove:     true,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		loadedRef, err := loadImage(ctx, cli, d.Get("tarball").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = cli.ImageTag(ctx, loadedRef, imageRef)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	token := d.Get("registry_token").(string)
+	if token == "" {
+		token, _ = meta.ExtractScwClient(m).GetSecretKey()
+	}
+
+	registryAuth, err := encodeRegistryAuth(strings.SplitN(endpoint, "/", 2)[0], token)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	digest, err := pushImage(ctx, cli, imageRef, registryAuth)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(imageRef)
+	_ = d.Set("registry_endpoint", endpoint)
+	_ = d.Set("image_url", imageRef)
+	_ = d.Set("digest", digest)
+
+	return ResourceImageRead(ctx, d, m)
+}
+
+func ResourceImageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	namespaceID, ok := d.GetOk("namespace_id")
+	if !ok {
+		// Images pushed to another registry cannot be read back
+		return nil
+	}
+
+	api, region, err := newAPIWithRegionFromNamespaceID(d, m, namespaceID.(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tag, err := findImageTag(ctx, api, region, regional.ExpandID(namespaceID).ID, d.Get("name").(string), d.Get("tag").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if tag == nil {
+		// The registry may list a tag shortly after it was pushed
+		if d.IsNewResource() {
+			return nil
+		}
+
+		d.SetId("")
+
+		return nil
+	}
+
+	_ = d.Set("digest", tag.Digest)
+	_ = d.Set("region", region.String())
+
+	return nil
+}
+
+func ResourceImageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only registry_token and keep_remotely can be updated, they are used on creation and deletion
+	return ResourceImageRead(ctx, d, m)
+}
+
+func ResourceImageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	namespaceID, ok := d.GetOk("namespace_id")
+	if !ok || d.Get("keep_remotely").(bool) {
+		return nil
+	}
+
+	api, region, err := newAPIWithRegionFromNamespaceID(d, m, namespaceID.(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tag, err := findImageTag(ctx, api, region, regional.ExpandID(namespaceID).ID, d.Get("name").(string), d.Get("tag").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if tag == nil {
+		return nil
+	}
+
+	// Forcing the deletion would also delete the other tags of the digest
+	sharingTags, err := findTagsSharingDigest(ctx, api, region, tag)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(sharingTags) > 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Tag kept in the registry",
+			Detail: fmt.Sprintf("The tag %s of image %s shares its digest with the tags %s, it cannot be deleted without deleting them.",
+				tag.Name, d.Get("name").(string), strings.Join(sharingTags, ", ")),
+		}}
+	}
+
+	_, err = api.DeleteTag(&registry.DeleteTagRequest{
+		Region: region,
+		TagID:  tag.ID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// customizeDiffImageSourceSHA256 hashes the build context or the tarball, a new hash rebuilds the image
+func customizeDiffImageSourceSHA256(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("build") || !diff.NewValueKnown("tarball") {
+		return diff.SetNewComputed("source_sha256")
+	}
+
+	contextDir := ""
+	if rawContext, ok := diff.GetOk("build.0.context"); ok {
+		contextDir = rawContext.(string)
+	}
+
+	tarballPath := diff.Get("tarball").(string)
+	if contextDir == "" && tarballPath == "" {
+		return errors.New("one of build or tarball must be set")
+	}
+
+	sourceSHA256, err := sourceSHA256(contextDir, tarballPath)
+	if err != nil {
+		return err
+	}
+
+	if diff.Get("source_sha256").(string) == sourceSHA256 {
+		return nil
+	}
+
+	err = diff.SetNew("source_sha256", sourceSHA256)
+	if err != nil {
+		return err
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	return diff.ForceNew("source_sha256")
+}
@@ -0,0 +1,128 @@
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const localRegistryImage = "registry:2"
+
+// startLocalRegistry runs a registry:2 container standing in for a Scaleway namespace and returns its endpoint.
+// The test is skipped when no docker daemon is available
+func startLocalRegistry(t *testing.T) string {
+	t.Helper()
+
+	ctx := context.Background()
+
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	require.NoError(t, err)
+	t.Cleanup(func() { cli.Close() })
+
+	if _, err := cli.Ping(ctx); err != nil {
+		t.Skipf("docker is not available: %s", err)
+	}
+
+	pull, err := cli.ImagePull(ctx, localRegistryImage, image.PullOptions{})
+	require.NoError(t, err)
+
+	_, err = io.Copy(io.Discard, pull)
+	require.NoError(t, err)
+	require.NoError(t, pull.Close())
+
+	created, err := cli.ContainerCreate(ctx, &container.Config{
+		Image: localRegistryImage,
+	}, &container.HostConfig{
+		PublishAllPorts: true,
+	}, nil, nil, "")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = cli.ContainerRemove(context.Background(), created.ID, container.RemoveOptions{Force: true})
+	})
+
+	require.NoError(t, cli.ContainerStart(ctx, created.ID, container.StartOptions{}))
+
+	inspect, err := cli.ContainerInspect(ctx, created.ID)
+	require.NoError(t, err)
+
+	bindings := inspect.NetworkSettings.Ports["5000/tcp"]
+	require.NotEmpty(t, bindings)
+
+	endpoint := "127.0.0.1:" + bindings[0].HostPort
+
+	require.Eventually(t, func() bool {
+		res, err := http.Get("http://" + endpoint + "/v2/")
+		if err != nil {
+			return false
+		}
+		defer res.Body.Close()
+
+		return res.StatusCode == http.StatusOK
+	}, 30*time.Second, 500*time.Millisecond)
+
+	return endpoint
+}
+
+func listLocalRegistryTags(t *testing.T, endpoint string, name string) []string {
+	t.Helper()
+
+	res, err := http.Get("http://" + endpoint + "/v2/" + name + "/tags/list")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	tagList := struct {
+		Tags []string `json:"tags"`
+	}{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&tagList))
+
+	return tagList.Tags
+}
+
+func TestImage_LocalRegistry(t *testing.T) {
+	endpoint := startLocalRegistry(t)
+
+	contextDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(contextDir, "Dockerfile"), []byte("FROM scratch\nCOPY app /app\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(contextDir, "app"), []byte("binary"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(contextDir, ".dockerignore"), []byte("*.log\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(contextDir, "debug.log"), []byte("ignored"), 0o644))
+
+	d := schema.TestResourceDataRaw(t, registry.ResourceImage().Schema, map[string]interface{}{
+		"name":              "tf-test-image",
+		"tag":               "v1",
+		"registry_endpoint": endpoint,
+		"registry_token":    "unused",
+		"build": []interface{}{
+			map[string]interface{}{
+				"context": contextDir,
+			},
+		},
+	})
+
+	diags := registry.ResourceImageCreate(context.Background(), d, nil)
+	require.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, endpoint+"/tf-test-image:v1", d.Get("image_url"))
+	assert.True(t, strings.HasPrefix(d.Get("digest").(string), "sha256:"))
+	assert.Equal(t, []string{"v1"}, listLocalRegistryTags(t, endpoint, "tf-test-image"))
+
+	// Images pushed to another registry are left untouched on deletion
+	diags = registry.ResourceImageDelete(context.Background(), d, nil)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"v1"}, listLocalRegistryTags(t, endpoint, "tf-test-image"))
+}
@@ -0,0 +1,483 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceRetentionPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceRetentionPolicyCreate,
+		ReadContext:   ResourceRetentionPolicyRead,
+		UpdateContext: ResourceRetentionPolicyUpdate,
+		DeleteContext: ResourceRetentionPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultNamespaceTimeout),
+		},
+		SchemaVersion: 0,
+		CustomizeDiff: customizeDiffRetentionPolicyTagsToDelete,
+		Schema: map[string]*schema.Schema{
+			"namespace_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The ID of the registry namespace the policy applies to",
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+			},
+			"keep_last": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of most recent tags to keep per image",
+			},
+			"delete_older_than_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Only delete tags created more than this number of days ago",
+			},
+			"keep_tags_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Tags matching this regular expression are always kept",
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list the tags to delete without deleting them",
+			},
+			"tags_to_delete": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The tags matching the policy in the image:tag format, without dry_run the tags deleted by the last apply",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"region": regional.ComputedSchema(),
+		},
+	}
+}
+
+func ResourceRetentionPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	namespaceID := d.Get("namespace_id").(string)
+
+	api, region, err := newAPIWithRegionFromNamespaceID(d, m, namespaceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ns, err := WaitForNamespace(ctx, api, region, regional.ExpandID(namespaceID).ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(regional.NewIDString(region, ns.ID))
+
+	diags := applyRetentionPolicy(ctx, d, api, region, ns.ID)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, ResourceRetentionPolicyRead(ctx, d, m)...)
+}
+
+func ResourceRetentionPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api, region, namespaceID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ns, err := api.GetNamespace(&registry.GetNamespaceRequest{
+		Region:      region,
+		NamespaceID: namespaceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("namespace_id", regional.NewIDString(region, ns.ID))
+	_ = d.Set("region", region.String())
+
+	// Without dry-run, the attribute keeps the tags deleted by the last apply
+	if d.Get("dry_run").(bool) {
+		tagsToDelete, err := listRetentionPolicyTagsToDelete(ctx, api, region, ns.ID, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_ = d.Set("tags_to_delete", flattenRetentionPolicyTags(tagsToDelete))
+	}
+
+	return nil
+}
+
+func ResourceRetentionPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api, region, namespaceID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := applyRetentionPolicy(ctx, d, api, region, namespaceID)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, ResourceRetentionPolicyRead(ctx, d, m)...)
+}
+
+func ResourceRetentionPolicyDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// Deleted tags cannot be restored, removing the policy only stops the cleanup
+	return nil
+}
+
+// applyRetentionPolicy deletes the tags planned in tags_to_delete unless dry_run is set.
+// The tags are only selected again when they were unknown when planning
+func applyRetentionPolicy(ctx context.Context, d *schema.ResourceData, api *registry.API, region scw.Region, namespaceID string) diag.Diagnostics {
+	if d.Get("dry_run").(bool) {
+		return nil
+	}
+
+	namespaceTags, err := listRetentionPolicyNamespaceTags(ctx, api, region, namespaceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	plannedTags := types.ExpandStrings(d.Get("tags_to_delete"))
+
+	if rawPlan := d.GetRawPlan(); rawPlan.IsNull() || !rawPlan.GetAttr("tags_to_delete").IsKnown() {
+		policy, err := expandRetentionPolicy(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		plannedTags = flattenRetentionPolicyTags(policy.NamespaceTagsToDelete(namespaceTags, time.Now()))
+	}
+
+	tagsToDelete, sharedTags := RetentionPolicyTagsByName(namespaceTags, plannedTags)
+
+	var diags diag.Diagnostics
+
+	for _, tag := range sharedTags {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Tag kept in the registry",
+			Detail: fmt.Sprintf("The tag %s:%s shares its digest with tags that are not deleted, it cannot be deleted without deleting them.",
+				tag.ImageName, tag.Tag.Name),
+		})
+	}
+
+	for _, tag := range tagsToDelete {
+		// The other tags of the digest are also planned, forcing the deletion deletes them all at once
+		_, err = api.DeleteTag(&registry.DeleteTagRequest{
+			Region: region,
+			TagID:  tag.Tag.ID,
+			Force:  scw.BoolPtr(true),
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			return append(diags, diag.FromErr(fmt.Errorf("failed to delete tag %s:%s: %w", tag.ImageName, tag.Tag.Name, err))...)
+		}
+	}
+
+	_ = d.Set("tags_to_delete", plannedTags)
+
+	return diags
+}
+
+// customizeDiffRetentionPolicyTagsToDelete plans the tags to delete, an update is planned when new tags match the policy
+func customizeDiffRetentionPolicyTagsToDelete(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	policyChanged := diff.HasChanges("keep_last", "delete_older_than_days", "keep_tags_regex", "dry_run")
+
+	// In dry-run, the tags listed by the last refresh are up to date
+	if diff.Id() != "" && diff.Get("dry_run").(bool) && !policyChanged {
+		return nil
+	}
+
+	namespaceID := diff.Get("namespace_id").(string)
+	if !diff.NewValueKnown("namespace_id") || namespaceID == "" {
+		return diff.SetNewComputed("tags_to_delete")
+	}
+
+	api := registry.NewAPI(meta.ExtractScwClient(m))
+
+	region := regional.ExpandID(namespaceID).Region
+	if region == "" {
+		var err error
+
+		region, err = meta.ExtractRegion(diff, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	tagsToDelete, err := listRetentionPolicyTagsToDelete(ctx, api, region, regional.ExpandID(namespaceID).ID, diff)
+	if err != nil {
+		return err
+	}
+
+	flattenedTags := flattenRetentionPolicyTags(tagsToDelete)
+	if diff.Id() != "" && len(flattenedTags) == 0 && !policyChanged {
+		return nil
+	}
+
+	if diff.Id() != "" && slices.Equal(flattenedTags, types.ExpandStrings(diff.Get("tags_to_delete"))) {
+		return nil
+	}
+
+	return diff.SetNew("tags_to_delete", flattenedTags)
+}
+
+type retentionPolicyData interface {
+	Get(key string) interface{}
+}
+
+// listRetentionPolicyTagsToDelete lists the tags of every image of the namespace matching the policy
+func listRetentionPolicyTagsToDelete(ctx context.Context, api *registry.API, region scw.Region, namespaceID string, d retentionPolicyData) ([]*RetentionPolicyTag, error) {
+	policy, err := expandRetentionPolicy(d)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceTags, err := listRetentionPolicyNamespaceTags(ctx, api, region, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.NamespaceTagsToDelete(namespaceTags, time.Now()), nil
+}
+
+// listRetentionPolicyNamespaceTags lists the tags of every image of the namespace, the most recent first
+func listRetentionPolicyNamespaceTags(ctx context.Context, api *registry.API, region scw.Region, namespaceID string) ([]*RetentionPolicyTag, error) {
+	images, err := api.ListImages(&registry.ListImagesRequest{
+		Region:      region,
+		NamespaceID: &namespaceID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaceTags []*RetentionPolicyTag
+
+	for _, image := range images.Images {
+		tags, err := api.ListTags(&registry.ListTagsRequest{
+			Region:  region,
+			ImageID: image.ID,
+			OrderBy: registry.ListTagsRequestOrderByCreatedAtDesc,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range tags.Tags {
+			namespaceTags = append(namespaceTags, &RetentionPolicyTag{ImageName: image.Name, Tag: tag})
+		}
+	}
+
+	return namespaceTags, nil
+}
+
+func expandRetentionPolicy(d retentionPolicyData) (*RetentionPolicy, error) {
+	policy := &RetentionPolicy{
+		KeepLast:  d.Get("keep_last").(int),
+		OlderThan: time.Duration(d.Get("delete_older_than_days").(int)) * 24 * time.Hour,
+	}
+
+	if rawRegex := d.Get("keep_tags_regex").(string); rawRegex != "" {
+		keepTagsRegex, err := regexp.Compile(rawRegex)
+		if err != nil {
+			return nil, err
+		}
+
+		policy.KeepTagsRegex = keepTagsRegex
+	}
+
+	return policy, nil
+}
+
+func flattenRetentionPolicyTags(tags []*RetentionPolicyTag) []string {
+	flattened := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		flattened = append(flattened, tag.ImageName+":"+tag.Tag.Name)
+	}
+
+	sort.Strings(flattened)
+
+	return flattened
+}
+
+// RetentionPolicyTagsByName returns the tags of the namespace with the given image:tag names.
+// A tag sharing its digest with a tag that is not in the names cannot be deleted alone, it is returned in shared instead
+func RetentionPolicyTagsByName(namespaceTags []*RetentionPolicyTag, names []string) (tags []*RetentionPolicyTag, shared []*RetentionPolicyTag) {
+	nameSet := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		nameSet[name] = struct{}{}
+	}
+
+	// Digests of the tags that are kept, by image
+	keptDigests := make(map[string]struct{})
+
+	for _, tag := range namespaceTags {
+		if _, found := nameSet[tag.ImageName+":"+tag.Tag.Name]; !found && tag.Tag.Digest != "" {
+			keptDigests[tag.ImageName+"@"+tag.Tag.Digest] = struct{}{}
+		}
+	}
+
+	for _, tag := range namespaceTags {
+		if _, found := nameSet[tag.ImageName+":"+tag.Tag.Name]; !found {
+			continue
+		}
+
+		if _, kept := keptDigests[tag.ImageName+"@"+tag.Tag.Digest]; kept {
+			shared = append(shared, tag)
+		} else {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, shared
+}
+
+// RetentionPolicyTag is a tag with the name of its image
+type RetentionPolicyTag struct {
+	ImageName string
+	Tag       *registry.Tag
+}
+
+// RetentionPolicy selects the tags of an image to delete
+type RetentionPolicy struct {
+	// KeepLast is the number of most recent tags kept, tags matching KeepTagsRegex are not counted
+	KeepLast int
+	// OlderThan restricts the deletion to the tags created before this duration, 0 to ignore the age of tags
+	OlderThan time.Duration
+	// KeepTagsRegex matches tags that are never deleted
+	KeepTagsRegex *regexp.Regexp
+}
+
+// NamespaceTagsToDelete returns the tags of the namespace to delete, the policy applies to each image separately
+func (p *RetentionPolicy) NamespaceTagsToDelete(namespaceTags []*RetentionPolicyTag, now time.Time) []*RetentionPolicyTag {
+	var imageNames []string
+
+	tagsByImage := make(map[string][]*registry.Tag)
+
+	for _, tag := range namespaceTags {
+		if _, found := tagsByImage[tag.ImageName]; !found {
+			imageNames = append(imageNames, tag.ImageName)
+		}
+
+		tagsByImage[tag.ImageName] = append(tagsByImage[tag.ImageName], tag.Tag)
+	}
+
+	var tagsToDelete []*RetentionPolicyTag
+
+	for _, imageName := range imageNames {
+		for _, tag := range p.TagsToDelete(tagsByImage[imageName], now) {
+			tagsToDelete = append(tagsToDelete, &RetentionPolicyTag{ImageName: imageName, Tag: tag})
+		}
+	}
+
+	return tagsToDelete
+}
+
+// TagsToDelete returns the tags of a single image to delete. Nothing is deleted when no rule is set.
+// A tag sharing its digest with a kept tag is kept too, the registry cannot delete it alone
+func (p *RetentionPolicy) TagsToDelete(tags []*registry.Tag, now time.Time) []*registry.Tag {
+	if p.KeepLast == 0 && p.OlderThan == 0 {
+		return nil
+	}
+
+	sortedTags := make([]*registry.Tag, len(tags))
+	copy(sortedTags, tags)
+
+	sort.SliceStable(sortedTags, func(i, j int) bool {
+		return retentionPolicyTagTime(sortedTags[i]).After(retentionPolicyTagTime(sortedTags[j]))
+	})
+
+	var tagsToDelete []*registry.Tag
+
+	kept := 0
+
+	for _, tag := range sortedTags {
+		if p.KeepTagsRegex != nil && p.KeepTagsRegex.MatchString(tag.Name) {
+			continue
+		}
+
+		if kept < p.KeepLast {
+			kept++
+
+			continue
+		}
+
+		if p.OlderThan > 0 && now.Sub(retentionPolicyTagTime(tag)) < p.OlderThan {
+			continue
+		}
+
+		tagsToDelete = append(tagsToDelete, tag)
+	}
+
+	return withoutKeptDigests(tags, tagsToDelete)
+}
+
+// withoutKeptDigests removes the tags to delete whose digest is also the digest of a kept tag
+func withoutKeptDigests(tags []*registry.Tag, tagsToDelete []*registry.Tag) []*registry.Tag {
+	deleted := make(map[*registry.Tag]struct{}, len(tagsToDelete))
+	for _, tag := range tagsToDelete {
+		deleted[tag] = struct{}{}
+	}
+
+	keptDigests := make(map[string]struct{})
+
+	for _, tag := range tags {
+		if _, found := deleted[tag]; !found && tag.Digest != "" {
+			keptDigests[tag.Digest] = struct{}{}
+		}
+	}
+
+	var filtered []*registry.Tag
+
+	for _, tag := range tagsToDelete {
+		if _, kept := keptDigests[tag.Digest]; !kept {
+			filtered = append(filtered, tag)
+		}
+	}
+
+	return filtered
+}
+
+func retentionPolicyTagTime(tag *registry.Tag) time.Time {
+	if tag.CreatedAt != nil {
+		return *tag.CreatedAt
+	}
+
+	if tag.UpdatedAt != nil {
+		return *tag.UpdatedAt
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,116 @@
+package registry_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	registrySDK "github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionPolicyTagsToDelete(t *testing.T) {
+	now := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	tag := func(name string, daysAgo int) *registrySDK.Tag {
+		return &registrySDK.Tag{Name: name, CreatedAt: scw.TimePtr(now.Add(-time.Duration(daysAgo) * 24 * time.Hour))}
+	}
+
+	tags := []*registrySDK.Tag{
+		tag("v1", 30),
+		tag("latest", 1),
+		tag("v3", 10),
+		tag("v2", 20),
+		tag("v4", 2),
+	}
+
+	tests := []struct {
+		name     string
+		policy   registry.RetentionPolicy
+		expected []string
+	}{
+		{
+			name:     "no rule",
+			policy:   registry.RetentionPolicy{},
+			expected: nil,
+		},
+		{
+			name:     "keep last",
+			policy:   registry.RetentionPolicy{KeepLast: 2},
+			expected: []string{"v3", "v2", "v1"},
+		},
+		{
+			name:     "keep last with protected tags",
+			policy:   registry.RetentionPolicy{KeepLast: 2, KeepTagsRegex: regexp.MustCompile("^latest$")},
+			expected: []string{"v2", "v1"},
+		},
+		{
+			name:     "older than",
+			policy:   registry.RetentionPolicy{OlderThan: 15 * 24 * time.Hour},
+			expected: []string{"v2", "v1"},
+		},
+		{
+			name:     "keep last and older than",
+			policy:   registry.RetentionPolicy{KeepLast: 4, OlderThan: 15 * 24 * time.Hour},
+			expected: []string{"v1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, tag := range tt.policy.TagsToDelete(tags, now) {
+				names = append(names, tag.Name)
+			}
+
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestRetentionPolicyTagsToDelete_SharedDigest(t *testing.T) {
+	now := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	tag := func(name string, digest string, daysAgo int) *registrySDK.Tag {
+		return &registrySDK.Tag{Name: name, Digest: digest, CreatedAt: scw.TimePtr(now.Add(-time.Duration(daysAgo) * 24 * time.Hour))}
+	}
+
+	tags := []*registrySDK.Tag{
+		tag("latest", "sha256:a", 1),
+		tag("v3", "sha256:a", 1),
+		tag("v2", "sha256:b", 10),
+		tag("v2.0", "sha256:b", 10),
+		tag("v1", "sha256:c", 20),
+	}
+
+	var names []string
+	for _, tag := range (&registry.RetentionPolicy{KeepLast: 1}).TagsToDelete(tags, now) {
+		names = append(names, tag.Name)
+	}
+
+	// v3 shares its digest with the kept latest tag
+	assert.Equal(t, []string{"v2", "v2.0", "v1"}, names)
+}
+
+func TestRetentionPolicyTagsByName(t *testing.T) {
+	namespaceTags := []*registry.RetentionPolicyTag{
+		{ImageName: "app", Tag: &registrySDK.Tag{Name: "latest", Digest: "sha256:a"}},
+		{ImageName: "app", Tag: &registrySDK.Tag{Name: "v2", Digest: "sha256:a"}},
+		{ImageName: "app", Tag: &registrySDK.Tag{Name: "v1", Digest: "sha256:b"}},
+		{ImageName: "app", Tag: &registrySDK.Tag{Name: "v1.0", Digest: "sha256:b"}},
+		{ImageName: "worker", Tag: &registrySDK.Tag{Name: "v1", Digest: "sha256:a"}},
+	}
+
+	flatten := func(tags []*registry.RetentionPolicyTag) []string {
+		var names []string
+		for _, tag := range tags {
+			names = append(names, tag.ImageName+":"+tag.Tag.Name)
+		}
+
+		return names
+	}
+
+	tags, shared := registry.RetentionPolicyTagsByName(namespaceTags, []string{"app:v2", "app:v1", "app:v1.0", "app:removed", "worker:v1"})
+	assert.Equal(t, []string{"app:v1", "app:v1.0", "worker:v1"}, flatten(tags))
+	assert.Equal(t, []string{"app:v2"}, flatten(shared))
+}
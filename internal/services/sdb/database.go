@@ -7,10 +7,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	sdbSDK "github.com/scaleway/scaleway-sdk-go/api/serverless_sqldb/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
 )
 
 func ResourceDatabase() *schema.Resource {
@@ -49,6 +52,14 @@ func ResourceDatabase() *schema.Resource {
 				Default:     0,
 				Description: "The minimum number of CPU units for your Serverless SQL Database",
 			},
+			"from_backup_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "The ID of the backup to create the database from",
+			},
 			"endpoint": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -72,7 +83,7 @@ func ResourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, m inter
 		Name:         d.Get("name").(string),
 		CPUMin:       uint32(d.Get("min_cpu").(int)),
 		CPUMax:       uint32(d.Get("max_cpu").(int)),
-		FromBackupID: nil,
+		FromBackupID: types.ExpandStringPtr(locality.ExpandID(d.Get("from_backup_id"))),
 	}, scw.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
@@ -0,0 +1,141 @@
+package sdb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdbSDK "github.com/scaleway/scaleway-sdk-go/api/serverless_sqldb/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+// databaseBackupSchema is shared between the backup and the backups data sources
+func databaseBackupSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the backup",
+		},
+		"database_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the database the backup is created from",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The status of the backup",
+		},
+		"size": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The size of the backup file (in bytes)",
+		},
+		"db_size": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The size of the database when the backup was done (in bytes)",
+		},
+		"download_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The URL to download the exported backup",
+		},
+		"download_url_expires_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Expiration date of the download URL (Format ISO 8601)",
+		},
+		"created_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Creation date (Format ISO 8601)",
+		},
+		"expires_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Expiration date (Format ISO 8601)",
+		},
+	}
+}
+
+func DataSourceDatabaseBackup() *schema.Resource {
+	dsSchema := databaseBackupSchema()
+
+	dsSchema["backup_id"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+		ExactlyOneOf:     []string{"backup_id", "database_id"},
+		Description:      "The ID of the backup",
+	}
+	dsSchema["database_id"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+		Description:      "The ID of the database, its latest backup is used when no backup_id is given",
+	}
+	dsSchema["region"] = regional.Schema()
+
+	return &schema.Resource{
+		ReadContext: DataSourceDatabaseBackupRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read:    schema.DefaultTimeout(defaultTimeout),
+			Default: schema.DefaultTimeout(defaultTimeout),
+		},
+		Schema: dsSchema,
+	}
+}
+
+func DataSourceDatabaseBackupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backupID := locality.ExpandID(d.Get("backup_id"))
+	if backupID == "" {
+		databaseID := locality.ExpandID(d.Get("database_id"))
+
+		res, err := api.ListDatabaseBackups(&sdbSDK.ListDatabaseBackupsRequest{
+			Region:     region,
+			DatabaseID: databaseID,
+			OrderBy:    sdbSDK.ListDatabaseBackupsRequestOrderByCreatedAtDesc,
+			PageSize:   scw.Uint32Ptr(1),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if len(res.Backups) == 0 {
+			return diag.Errorf("no backup found for database %s", databaseID)
+		}
+
+		backupID = res.Backups[0].ID
+	}
+
+	backup, err := waitForDatabaseBackup(ctx, api, region, backupID, d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(regional.NewIDString(region, backup.ID))
+	_ = d.Set("backup_id", regional.NewIDString(region, backup.ID))
+	_ = d.Set("region", region)
+
+	for key, value := range flattenDatabaseBackup(backup) {
+		if key == "id" {
+			continue
+		}
+
+		_ = d.Set(key, value)
+	}
+
+	return nil
+}
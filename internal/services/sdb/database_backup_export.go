@@ -0,0 +1,152 @@
+package sdb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdbSDK "github.com/scaleway/scaleway-sdk-go/api/serverless_sqldb/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+// ResourceDatabaseBackupExport exports a backup to get a download URL,
+// the export is run again whenever the resource is replaced
+func ResourceDatabaseBackupExport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceDatabaseBackupExportCreate,
+		ReadContext:   ResourceDatabaseBackupExportRead,
+		DeleteContext: ResourceDatabaseBackupExportDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultTimeout),
+			Default: schema.DefaultTimeout(defaultTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"backup_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "The ID of the backup to export",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, will export the backup again",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the backup",
+			},
+			"download_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The URL to download the exported backup",
+			},
+			"download_url_expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration date of the download URL (Format ISO 8601)",
+			},
+			// Common
+			"region": regional.Schema(),
+		},
+	}
+}
+
+func ResourceDatabaseBackupExportCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backupID := locality.ExpandID(d.Get("backup_id"))
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	backup, err := waitForDatabaseBackup(ctx, api, region, backupID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// a backup exported by another resource is not exported again while its download URL is valid
+	if !hasValidDownloadURL(backup) {
+		if backup.Status != sdbSDK.DatabaseBackupStatusReady {
+			return diag.Errorf("backup %s cannot be exported, it has status %s", backup.ID, backup.Status)
+		}
+
+		_, err = api.ExportDatabaseBackup(&sdbSDK.ExportDatabaseBackupRequest{
+			Region:   region,
+			BackupID: backupID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		backup, err = waitForDatabaseBackup(ctx, api, region, backupID, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if backup.DownloadURL == nil {
+			return diag.Errorf("backup %s export ended without a download URL, it has status %s", backup.ID, backup.Status)
+		}
+	}
+
+	d.SetId(regional.NewIDString(region, backup.ID))
+	_ = d.Set("download_url", types.FlattenStringPtr(backup.DownloadURL))
+	_ = d.Set("download_url_expires_at", types.FlattenTime(backup.DownloadURLExpiresAt))
+
+	return ResourceDatabaseBackupExportRead(ctx, d, m)
+}
+
+// ResourceDatabaseBackupExportRead refreshes the status of the backup. The download URL of the export is kept
+// in the state once the backup is gone, as removing the resource would export it again on the next apply.
+func ResourceDatabaseBackupExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api, region, id, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backup, err := api.GetDatabaseBackup(&sdbSDK.GetDatabaseBackupRequest{
+		Region:   region,
+		BackupID: id,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("backup_id", regional.NewIDString(region, backup.ID))
+	_ = d.Set("status", backup.Status.String())
+	_ = d.Set("region", region)
+
+	if backup.DownloadURL != nil {
+		_ = d.Set("download_url", *backup.DownloadURL)
+		_ = d.Set("download_url_expires_at", types.FlattenTime(backup.DownloadURLExpiresAt))
+	}
+
+	return nil
+}
+
+// ResourceDatabaseBackupExportDelete only removes the export from the state, the backup is kept
+func ResourceDatabaseBackupExportDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
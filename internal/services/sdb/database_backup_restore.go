@@ -0,0 +1,153 @@
+package sdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdbSDK "github.com/scaleway/scaleway-sdk-go/api/serverless_sqldb/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+// ResourceDatabaseBackupRestore restores a backup into an existing database,
+// the restore is run again whenever the resource is replaced
+func ResourceDatabaseBackupRestore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceDatabaseBackupRestoreCreate,
+		ReadContext:   ResourceDatabaseBackupRestoreRead,
+		DeleteContext: ResourceDatabaseBackupRestoreDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultTimeout),
+			Default: schema.DefaultTimeout(defaultTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"backup_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "The ID of the backup to restore",
+			},
+			"database_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+				Description:      "The ID of the database in which the backup is restored",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, will restore the backup again",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// Common
+			"region": regional.Schema(),
+		},
+		CustomizeDiff: cdf.LocalityCheck("backup_id", "database_id"),
+	}
+}
+
+func ResourceDatabaseBackupRestoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backupID := locality.ExpandID(d.Get("backup_id"))
+	databaseID := locality.ExpandID(d.Get("database_id"))
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	backup, err := waitForDatabaseBackup(ctx, api, region, backupID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if backup.Status != sdbSDK.DatabaseBackupStatusReady {
+		return diag.Errorf("backup %s cannot be restored, it has status %s", backup.ID, backup.Status)
+	}
+
+	_, err = waitForDatabase(ctx, api, region, databaseID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = api.RestoreDatabaseFromBackup(&sdbSDK.RestoreDatabaseFromBackupRequest{
+		Region:     region,
+		DatabaseID: databaseID,
+		BackupID:   backupID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ResourceDatabaseBackupRestoreID(region, databaseID, backupID))
+
+	database, err := waitForDatabase(ctx, api, region, databaseID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if database.Status != sdbSDK.DatabaseStatusReady {
+		d.SetId("")
+
+		return diag.Errorf("failed to restore backup %s into database %s, the database ended with status %s", backupID, database.Name, database.Status)
+	}
+
+	return ResourceDatabaseBackupRestoreRead(ctx, d, m)
+}
+
+// ResourceDatabaseBackupRestoreRead does not remove the resource when the backup is gone,
+// as it would restore the backup again on the next apply
+func ResourceDatabaseBackupRestoreRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	region, databaseID, backupID, err := ResourceDatabaseBackupRestoreParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("database_id", regional.NewIDString(region, databaseID))
+	_ = d.Set("backup_id", regional.NewIDString(region, backupID))
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+// ResourceDatabaseBackupRestoreDelete only removes the restore from the state, a restore cannot be undone
+func ResourceDatabaseBackupRestoreDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+// ResourceDatabaseBackupRestoreID builds the resource identifier
+// The resource identifier format is "Region/DatabaseId/BackupId"
+func ResourceDatabaseBackupRestoreID(region scw.Region, databaseID string, backupID string) (resourceID string) {
+	return fmt.Sprintf("%s/%s/%s", region, databaseID, backupID)
+}
+
+// ResourceDatabaseBackupRestoreParseID extracts region, database ID and backup ID from the resource identifier.
+// The resource identifier format is "Region/DatabaseId/BackupId"
+func ResourceDatabaseBackupRestoreParseID(resourceID string) (region scw.Region, databaseID string, backupID string, err error) {
+	idParts := strings.Split(resourceID, "/")
+	if len(idParts) != 3 {
+		return "", "", "", fmt.Errorf("can't parse backup restore resource id: %s", resourceID)
+	}
+
+	return scw.Region(idParts[0]), idParts[1], idParts[2], nil
+}
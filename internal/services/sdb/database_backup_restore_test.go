@@ -0,0 +1,139 @@
+package sdb_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	sdbSDK "github.com/scaleway/scaleway-sdk-go/api/serverless_sqldb/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/sdb"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+)
+
+func TestAccServerlessSQLDBDatabaseBackupRestore_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	var downloadURL string
+
+	databaseConfig := `
+		resource scaleway_sdb_sql_database main {
+			name = "test-sdb-sql-database-backup-restore"
+		}
+	`
+
+	restoreConfig := databaseConfig + `
+		data scaleway_sdb_sql_database_backup latest {
+			database_id = scaleway_sdb_sql_database.main.id
+		}
+
+		resource scaleway_sdb_sql_database_backup_export main {
+			backup_id = data.scaleway_sdb_sql_database_backup.latest.id
+		}
+
+		data scaleway_sdb_sql_database_backups all {
+			database_id = scaleway_sdb_sql_database.main.id
+		}
+
+		resource scaleway_sdb_sql_database_backup_restore main {
+			database_id = scaleway_sdb_sql_database.main.id
+			backup_id   = data.scaleway_sdb_sql_database_backup.latest.id
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:      testAccCheckServerlessSQLDBDatabaseDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: databaseConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerlessSQLDBDatabaseExists(tt, "scaleway_sdb_sql_database.main"),
+					waitForServerlessSQLDBDatabaseBackup(tt, "scaleway_sdb_sql_database.main"),
+				),
+			},
+			{
+				Config: restoreConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.scaleway_sdb_sql_database_backup.latest", "database_id", "scaleway_sdb_sql_database.main", "id"),
+					resource.TestCheckResourceAttr("data.scaleway_sdb_sql_database_backup.latest", "status", "ready"),
+					resource.TestCheckResourceAttrPair("scaleway_sdb_sql_database_backup_export.main", "backup_id", "data.scaleway_sdb_sql_database_backup.latest", "id"),
+					resource.TestCheckResourceAttrSet("scaleway_sdb_sql_database_backup_export.main", "download_url"),
+					resource.TestCheckResourceAttrSet("scaleway_sdb_sql_database_backup_export.main", "download_url_expires_at"),
+					resource.TestCheckResourceAttrPair("data.scaleway_sdb_sql_database_backups.all", "backups.0.id", "data.scaleway_sdb_sql_database_backup.latest", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_sdb_sql_database_backup_restore.main", "database_id", "scaleway_sdb_sql_database.main", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_sdb_sql_database_backup_restore.main", "backup_id", "data.scaleway_sdb_sql_database_backup.latest", "id"),
+					testAccCheckServerlessSQLDBDatabaseExists(tt, "scaleway_sdb_sql_database.main"),
+					resource.TestCheckResourceAttrWith("scaleway_sdb_sql_database_backup_export.main", "download_url", func(value string) error {
+						downloadURL = value
+
+						return nil
+					}),
+				),
+			},
+			{
+				// Refreshing the data source does not export the backup, the export is kept until the resource is replaced
+				Config: restoreConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("scaleway_sdb_sql_database_backup_export.main", "download_url", func(value string) error {
+						if value != downloadURL {
+							return errors.New("the backup was exported again on refresh")
+						}
+
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "scaleway_sdb_sql_database_backup_restore.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// waitForServerlessSQLDBDatabaseBackup waits for the first automatic backup of the database,
+// backups cannot be created on demand
+func waitForServerlessSQLDBDatabaseBackup(tt *acctest.TestTools, n string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		api, region, id, err := sdb.NewAPIWithRegionAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		retryInterval := time.Minute
+		if transport.DefaultWaitRetryInterval != nil {
+			retryInterval = *transport.DefaultWaitRetryInterval
+		}
+
+		for deadline := time.Now().Add(2 * time.Hour); time.Now().Before(deadline); time.Sleep(retryInterval) {
+			res, err := api.ListDatabaseBackups(&sdbSDK.ListDatabaseBackupsRequest{
+				Region:     region,
+				DatabaseID: id,
+			}, scw.WithAllPages())
+			if err != nil {
+				return err
+			}
+
+			for _, backup := range res.Backups {
+				if backup.Status == sdbSDK.DatabaseBackupStatusReady {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("no backup of database %s was done in time", id)
+	}
+}
@@ -0,0 +1,65 @@
+package sdb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdbSDK "github.com/scaleway/scaleway-sdk-go/api/serverless_sqldb/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func DataSourceDatabaseBackups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceDatabaseBackupsRead,
+		Schema: map[string]*schema.Schema{
+			"database_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				Description:      "The ID of the database to list the backups of",
+			},
+			"backups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The backups of the database, the most recent first",
+				Elem: &schema.Resource{
+					Schema: databaseBackupSchema(),
+				},
+			},
+			"region": regional.Schema(),
+		},
+	}
+}
+
+func DataSourceDatabaseBackupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databaseID := locality.ExpandID(d.Get("database_id"))
+
+	res, err := api.ListDatabaseBackups(&sdbSDK.ListDatabaseBackupsRequest{
+		Region:     region,
+		DatabaseID: databaseID,
+		OrderBy:    sdbSDK.ListDatabaseBackupsRequestOrderByCreatedAtDesc,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backups := []interface{}(nil)
+	for _, backup := range res.Backups {
+		backups = append(backups, flattenDatabaseBackup(backup))
+	}
+
+	d.SetId(regional.NewIDString(region, databaseID))
+	_ = d.Set("backups", backups)
+	_ = d.Set("region", region)
+
+	return nil
+}
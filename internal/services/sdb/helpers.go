@@ -11,10 +11,13 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/function"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 )
 
 const (
 	defaultTimeout = 15 * time.Minute
+	// downloadURLMinValidity is the time a download URL must still be valid for to be reused instead of exporting the backup again
+	downloadURLMinValidity = 1 * time.Hour
 )
 
 // newAPIWithRegion returns a new serverless_sqldb API and the region for a Create request
@@ -56,3 +59,50 @@ func waitForDatabase(ctx context.Context, sdbAPI *sdbSDK.API, region scw.Region,
 
 	return database, err
 }
+
+func waitForDatabaseBackup(ctx context.Context, sdbAPI *sdbSDK.API, region scw.Region, id string, timeout time.Duration) (*sdbSDK.DatabaseBackup, error) {
+	retryInterval := function.DefaultFunctionRetryInterval
+	if transport.DefaultWaitRetryInterval != nil {
+		retryInterval = *transport.DefaultWaitRetryInterval
+	}
+
+	backup, err := sdbAPI.WaitForDatabaseBackup(&sdbSDK.WaitForDatabaseBackupRequest{
+		Region:        region,
+		BackupID:      id,
+		RetryInterval: &retryInterval,
+		Timeout:       scw.TimeDurationPtr(timeout),
+	}, scw.WithContext(ctx))
+
+	return backup, err
+}
+
+// hasValidDownloadURL returns whether the backup was already exported and its download URL is not about to expire
+func hasValidDownloadURL(backup *sdbSDK.DatabaseBackup) bool {
+	if backup.DownloadURL == nil || backup.DownloadURLExpiresAt == nil {
+		return false
+	}
+
+	return time.Until(*backup.DownloadURLExpiresAt) > downloadURLMinValidity
+}
+
+func flattenDatabaseBackup(backup *sdbSDK.DatabaseBackup) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                      regional.NewIDString(backup.Region, backup.ID),
+		"database_id":             regional.NewIDString(backup.Region, backup.DatabaseID),
+		"status":                  backup.Status.String(),
+		"size":                    flattenSize(backup.Size),
+		"db_size":                 flattenSize(backup.DbSize),
+		"download_url":            types.FlattenStringPtr(backup.DownloadURL),
+		"download_url_expires_at": types.FlattenTime(backup.DownloadURLExpiresAt),
+		"created_at":              types.FlattenTime(backup.CreatedAt),
+		"expires_at":              types.FlattenTime(backup.ExpiresAt),
+	}
+}
+
+func flattenSize(size *scw.Size) int {
+	if size == nil {
+		return 0
+	}
+
+	return int(*size)
+}
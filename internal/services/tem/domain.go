@@ -199,7 +199,7 @@ func ResourceDomainCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		Region:     region,
 		ProjectID:  d.Get("project_id").(string),
 		DomainName: d.Get("name").(string),
-		AcceptTos:  d.Get("accept_tos").(bool),
+		AcceptTos:  scw.BoolPtr(d.Get("accept_tos").(bool)), //nolint:staticcheck
 		Autoconfig: d.Get("autoconfig").(bool),
 	}, scw.WithContext(ctx))
 	if err != nil {
@@ -5,7 +5,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	webhosting "github.com/scaleway/scaleway-sdk-go/api/webhosting/v1alpha1"
+	webhosting "github.com/scaleway/scaleway-sdk-go/api/webhosting/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
@@ -18,8 +18,8 @@ const (
 )
 
 // newAPIWithRegion returns a new Webhosting API and the region for a Create request
-func newAPIWithRegion(d *schema.ResourceData, m interface{}) (*webhosting.API, scw.Region, error) {
-	api := webhosting.NewAPI(meta.ExtractScwClient(m))
+func newAPIWithRegion(d *schema.ResourceData, m interface{}) (*webhosting.HostingAPI, scw.Region, error) {
+	api := webhosting.NewHostingAPI(meta.ExtractScwClient(m))
 
 	region, err := meta.ExtractRegion(d, m)
 	if err != nil {
@@ -30,8 +30,8 @@ func newAPIWithRegion(d *schema.ResourceData, m interface{}) (*webhosting.API, s
 }
 
 // NewAPIWithRegionAndID returns a Webhosting API with region and ID extracted from the state
-func NewAPIWithRegionAndID(m interface{}, id string) (*webhosting.API, scw.Region, string, error) {
-	api := webhosting.NewAPI(meta.ExtractScwClient(m))
+func NewAPIWithRegionAndID(m interface{}, id string) (*webhosting.HostingAPI, scw.Region, string, error) {
+	api := webhosting.NewHostingAPI(meta.ExtractScwClient(m))
 
 	region, id, err := regional.ParseID(id)
 	if err != nil {
@@ -41,7 +41,19 @@ func NewAPIWithRegionAndID(m interface{}, id string) (*webhosting.API, scw.Regio
 	return api, region, id, nil
 }
 
-func waitForHosting(ctx context.Context, api *webhosting.API, region scw.Region, hostingID string, timeout time.Duration) (*webhosting.Hosting, error) {
+// newOfferAPIWithRegion returns a new Webhosting offer API and the region
+func newOfferAPIWithRegion(d *schema.ResourceData, m interface{}) (*webhosting.OfferAPI, scw.Region, error) {
+	api := webhosting.NewOfferAPI(meta.ExtractScwClient(m))
+
+	region, err := meta.ExtractRegion(d, m)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return api, region, nil
+}
+
+func waitForHosting(ctx context.Context, api *webhosting.HostingAPI, region scw.Region, hostingID string, timeout time.Duration) (*webhosting.Hosting, error) {
 	retryInterval := hostingRetryInterval
 	if transport.DefaultWaitRetryInterval != nil {
 		retryInterval = *transport.DefaultWaitRetryInterval
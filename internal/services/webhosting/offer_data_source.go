@@ -6,7 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	webhosting "github.com/scaleway/scaleway-sdk-go/api/webhosting/v1alpha1"
+	webhosting "github.com/scaleway/scaleway-sdk-go/api/webhosting/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/datasource"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
@@ -86,12 +86,12 @@ func DataSourceOffer() *schema.Resource {
 }
 
 func dataSourceOfferRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api, region, err := newAPIWithRegion(d, m)
+	api, region, err := newOfferAPIWithRegion(d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	res, err := api.ListOffers(&webhosting.ListOffersRequest{
+	res, err := api.ListOffers(&webhosting.OfferAPIListOffersRequest{
 		Region: region,
 	}, scw.WithContext(ctx))
 	if err != nil {
@@ -105,7 +105,7 @@ func dataSourceOfferRead(ctx context.Context, d *schema.ResourceData, m interfac
 	var filteredOffer *webhosting.Offer
 
 	for _, offer := range res.Offers {
-		if offer.ID == d.Get("offer_id") || offer.Product.Name == d.Get("name") {
+		if offer.ID == d.Get("offer_id") || offer.Name == d.Get("name") {
 			filteredOffer = offer
 		}
 	}
@@ -117,10 +117,10 @@ func dataSourceOfferRead(ctx context.Context, d *schema.ResourceData, m interfac
 	regionalID := datasource.NewRegionalID(filteredOffer.ID, region)
 	d.SetId(regionalID)
 	_ = d.Set("offer_id", regionalID)
-	_ = d.Set("name", filteredOffer.Product.Name)
+	_ = d.Set("name", filteredOffer.Name)
 	_ = d.Set("region", region)
 	_ = d.Set("billing_operation_path", filteredOffer.BillingOperationPath)
-	_ = d.Set("product", flattenOfferProduct(filteredOffer.Product))
+	_ = d.Set("product", flattenOfferProduct(filteredOffer))
 	_ = d.Set("price", flattenOfferPrice(filteredOffer.Price))
 
 	return nil
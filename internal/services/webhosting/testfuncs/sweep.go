@@ -4,7 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	webhostingSDK "github.com/scaleway/scaleway-sdk-go/api/webhosting/v1alpha1"
+	webhostingSDK "github.com/scaleway/scaleway-sdk-go/api/webhosting/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/logging"
@@ -19,17 +19,17 @@ func AddTestSweepers() {
 
 func testSweepWebhosting(_ string) error {
 	return acctest.SweepRegions(scw.AllRegions, func(scwClient *scw.Client, region scw.Region) error {
-		webhsotingAPI := webhostingSDK.NewAPI(scwClient)
+		webhsotingAPI := webhostingSDK.NewHostingAPI(scwClient)
 
 		logging.L.Debugf("sweeper: deleting the hostings in (%s)", region)
 
-		listHostings, err := webhsotingAPI.ListHostings(&webhostingSDK.ListHostingsRequest{Region: region}, scw.WithAllPages())
+		listHostings, err := webhsotingAPI.ListHostings(&webhostingSDK.HostingAPIListHostingsRequest{Region: region}, scw.WithAllPages())
 		if err != nil {
 			return fmt.Errorf("error listing hostings in (%s) in sweeper: %w", region, err)
 		}
 
 		for _, hosting := range listHostings.Hostings {
-			_, err := webhsotingAPI.DeleteHosting(&webhostingSDK.DeleteHostingRequest{
+			_, err := webhsotingAPI.DeleteHosting(&webhostingSDK.HostingAPIDeleteHostingRequest{
 				HostingID: hosting.ID,
 				Region:    region,
 			})
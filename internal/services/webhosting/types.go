@@ -1,22 +1,28 @@
 package webhosting
 
 import (
-	webhosting "github.com/scaleway/scaleway-sdk-go/api/webhosting/v1alpha1"
+	webhosting "github.com/scaleway/scaleway-sdk-go/api/webhosting/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
-func flattenOfferProduct(product *webhosting.OfferProduct) interface{} {
+// flattenOfferProduct flattens the quotas of the offer, the API now exposes them as offer options
+func flattenOfferProduct(offer *webhosting.Offer) interface{} {
+	quotas := make(map[webhosting.OfferOptionName]int32, len(offer.Options))
+	for _, option := range offer.Options {
+		quotas[option.Name] = option.CurrentValue
+	}
+
 	return []map[string]interface{}{
 		{
-			"name":                  product.Name,
-			"option":                product.Option,
-			"email_accounts_quota":  product.EmailAccountsQuota,
-			"email_storage_quota":   product.EmailStorageQuota,
-			"databases_quota":       product.DatabasesQuota,
-			"hosting_storage_quota": product.HostingStorageQuota,
-			"support_included":      product.SupportIncluded,
-			"v_cpu":                 product.VCPU,
-			"ram":                   product.RAM,
+			"name":                  offer.Name,
+			"option":                false,
+			"email_accounts_quota":  quotas[webhosting.OfferOptionNameEmailCount],
+			"email_storage_quota":   quotas[webhosting.OfferOptionNameEmailStorageGb],
+			"databases_quota":       quotas[webhosting.OfferOptionNameDatabaseCount],
+			"hosting_storage_quota": quotas[webhosting.OfferOptionNameStorageGb],
+			"support_included":      quotas[webhosting.OfferOptionNameSupport] > 0,
+			"v_cpu":                 quotas[webhosting.OfferOptionNameVcpuCount],
+			"ram":                   quotas[webhosting.OfferOptionNameRAMGb],
 		},
 	}
 }
@@ -25,7 +31,11 @@ func flattenOfferPrice(price *scw.Money) interface{} {
 	return price.String()
 }
 
-func flattenHostingCpanelUrls(cpanelURL *webhosting.HostingCpanelURLs) []map[string]interface{} {
+func flattenHostingCpanelUrls(cpanelURL *webhosting.PlatformControlPanelURLs) []map[string]interface{} {
+	if cpanelURL == nil {
+		return nil
+	}
+
 	return []map[string]interface{}{
 		{
 			"dashboard": cpanelURL.Dashboard,
@@ -34,7 +44,20 @@ func flattenHostingCpanelUrls(cpanelURL *webhosting.HostingCpanelURLs) []map[str
 	}
 }
 
-func flattenHostingOptions(options []*webhosting.HostingOption) []map[string]interface{} {
+// expandHostingOfferOptions selects each option once
+func expandHostingOfferOptions(optionIDs []string) []*webhosting.OfferOptionRequest {
+	options := make([]*webhosting.OfferOptionRequest, 0, len(optionIDs))
+	for _, optionID := range optionIDs {
+		options = append(options, &webhosting.OfferOptionRequest{
+			ID:       optionID,
+			Quantity: 1,
+		})
+	}
+
+	return options
+}
+
+func flattenHostingOptions(options []*webhosting.OfferOption) []map[string]interface{} {
 	if options == nil {
 		return nil
 	}
@@ -43,7 +66,7 @@ func flattenHostingOptions(options []*webhosting.HostingOption) []map[string]int
 	for _, option := range options {
 		flattenedOptions = append(flattenedOptions, map[string]interface{}{
 			"id":   option.ID,
-			"name": option.Name,
+			"name": option.Name.String(),
 		})
 	}
 
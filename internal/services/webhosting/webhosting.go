@@ -5,7 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	webhosting "github.com/scaleway/scaleway-sdk-go/api/webhosting/v1alpha1"
+	webhosting "github.com/scaleway/scaleway-sdk-go/api/webhosting/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
@@ -173,12 +173,13 @@ func resourceWebhostingCreate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
-	hostingCreateRequest := &webhosting.CreateHostingRequest{
-		Region:    region,
-		OfferID:   offerID,
-		ProjectID: d.Get("project_id").(string),
-		Domain:    d.Get("domain").(string),
-		OptionIDs: types.ExpandStrings(d.Get("option_ids")),
+	hostingCreateRequest := &webhosting.HostingAPICreateHostingRequest{
+		Region:       region,
+		OfferID:      offerID,
+		ProjectID:    d.Get("project_id").(string),
+		Domain:       d.Get("domain").(string),
+		Email:        d.Get("email").(string),
+		OfferOptions: expandHostingOfferOptions(types.ExpandStrings(d.Get("option_ids"))),
 	}
 
 	rawTags, tagsExist := d.GetOk("tags")
@@ -186,16 +187,6 @@ func resourceWebhostingCreate(ctx context.Context, d *schema.ResourceData, m int
 		hostingCreateRequest.Tags = types.ExpandStrings(rawTags)
 	}
 
-	rawOptionIDs, rawOptionIDsExist := d.GetOk("option_ids")
-	if rawOptionIDsExist {
-		hostingCreateRequest.OptionIDs = types.ExpandStrings(rawOptionIDs)
-	}
-
-	rawEmail, emailExist := d.GetOk("email")
-	if emailExist {
-		hostingCreateRequest.Email = types.ExpandStringPtr(rawEmail)
-	}
-
 	hostingResponse, err := api.CreateHosting(hostingCreateRequest, scw.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
@@ -229,20 +220,34 @@ func resourceWebhostingRead(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	_ = d.Set("tags", webhostingResponse.Tags)
-	_ = d.Set("offer_id", regional.NewIDString(region, webhostingResponse.OfferID))
-	_ = d.Set("domain", webhostingResponse.Domain)
+	if webhostingResponse.Offer != nil {
+		_ = d.Set("offer_id", regional.NewIDString(region, webhostingResponse.Offer.ID))
+		_ = d.Set("offer_name", webhostingResponse.Offer.Name)
+		_ = d.Set("options", flattenHostingOptions(webhostingResponse.Offer.Options))
+	}
+
+	if webhostingResponse.Platform != nil {
+		_ = d.Set("platform_hostname", webhostingResponse.Platform.Hostname)
+		_ = d.Set("platform_number", int(webhostingResponse.Platform.Number))
+
+		if webhostingResponse.Platform.ControlPanel != nil {
+			_ = d.Set("cpanel_urls", flattenHostingCpanelUrls(webhostingResponse.Platform.ControlPanel.URLs))
+		}
+	}
+
+	if webhostingResponse.User != nil {
+		_ = d.Set("username", webhostingResponse.User.Username)
+	}
+
+	if webhostingResponse.DNSStatus != nil { //nolint:staticcheck
+		_ = d.Set("dns_status", webhostingResponse.DNSStatus.String()) //nolint:staticcheck
+	}
+
+	_ = d.Set("domain", types.FlattenStringPtr(webhostingResponse.Domain))
 	_ = d.Set("created_at", types.FlattenTime(webhostingResponse.CreatedAt))
 	_ = d.Set("updated_at", types.FlattenTime(webhostingResponse.UpdatedAt))
 	_ = d.Set("status", webhostingResponse.Status.String())
-	_ = d.Set("platform_hostname", webhostingResponse.PlatformHostname)
-	_ = d.Set("platform_number", types.FlattenInt32Ptr(webhostingResponse.PlatformNumber))
-	_ = d.Set("options", flattenHostingOptions(webhostingResponse.Options))
-	_ = d.Set("offer_name", webhostingResponse.OfferName)
-	_ = d.Set("dns_status", webhostingResponse.DNSStatus.String())
-	_ = d.Set("cpanel_urls", flattenHostingCpanelUrls(webhostingResponse.CpanelURLs))
-	_ = d.Set("username", webhostingResponse.Username)
 	_ = d.Set("region", string(region))
-	_ = d.Set("organization_id", webhostingResponse.OrganizationID)
 	_ = d.Set("project_id", webhostingResponse.ProjectID)
 
 	return nil
@@ -259,7 +264,7 @@ func resourceWebhostingUpdate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
-	updateRequest := &webhosting.UpdateHostingRequest{
+	updateRequest := &webhosting.HostingAPIUpdateHostingRequest{
 		Region:    region,
 		HostingID: res.ID,
 	}
@@ -267,7 +272,7 @@ func resourceWebhostingUpdate(ctx context.Context, d *schema.ResourceData, m int
 	hasChanged := false
 
 	if d.HasChange("option_ids") {
-		updateRequest.OptionIDs = types.ExpandUpdatedStringsPtr(d.Get("option_ids"))
+		updateRequest.OfferOptions = expandHostingOfferOptions(types.ExpandStrings(d.Get("option_ids")))
 		hasChanged = true
 	}
 
@@ -312,7 +317,7 @@ func resourceHostingDelete(ctx context.Context, d *schema.ResourceData, m interf
 		return nil
 	}
 
-	_, err = api.DeleteHosting(&webhosting.DeleteHostingRequest{
+	_, err = api.DeleteHosting(&webhosting.HostingAPIDeleteHostingRequest{
 		Region:    region,
 		HostingID: id,
 	}, scw.WithContext(ctx))